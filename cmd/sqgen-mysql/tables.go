@@ -50,6 +50,9 @@ import (
 {{template "table_struct_definition" $table}}
 {{template "table_constructor" $table}}
 {{template "table_as" $table}}
+{{- range $_, $index := $table.FullTextIndexes}}
+{{template "table_match" (index_of $table $index)}}
+{{- end}}
 {{- end}}
 
 {{- define "table_struct_definition"}}
@@ -79,6 +82,7 @@ func {{$table.Constructor.Export}}() {{$table.StructName.Export}} {
 	tbl := {{$table.StructName.Export}}{TableInfo: &sq.TableInfo{
 		Schema: "{{$table.Schema}}",
 		Name: "{{$table.Name}}",
+		CaseSensitive: true,
 	},}
 	{{- range $_, $field := $table.Fields}}
 	tbl.{{$field.Name.Export}} = {{$field.Constructor}}("{{$field.Name}}", tbl.TableInfo)
@@ -100,6 +104,21 @@ func (tbl {{$table.StructName.Export}}) As(alias string) {{$table.StructName.Exp
 	return tbl
 }
 {{- end}}
+{{- end}}
+
+{{- define "table_match"}}
+{{- with $table := .Table}}
+{{- with $index := $.Index}}
+// MATCH_{{$index.Name.Export}} returns a MatchAgainstField over the columns of the {{$index.Name}} FULLTEXT index.
+func (tbl {{$table.StructName.Export}}) MATCH_{{$index.Name.Export}}() sq.MatchAgainstField {
+	return sq.MatchAgainst(
+		{{- range $i, $column := $index.Columns}}
+		{{- if $i}}, {{end}}tbl.{{$column.Export}}
+		{{- end -}}
+	)
+}
+{{- end}}
+{{- end}}
 {{- end}}`
 
 type Table struct {
@@ -109,6 +128,13 @@ type Table struct {
 	RawType     string
 	Constructor String
 	Fields      []TableField
+
+	FullTextIndexes []FullTextIndex
+}
+
+type FullTextIndex struct {
+	Name    String
+	Columns []String
 }
 
 type TableField struct {
@@ -257,6 +283,35 @@ func getTables(db *sql.DB, databaseURL string, schemas []string) ([]Table, error
 		tables[index].Fields = append(tables[index].Fields, field)
 	}
 
+	// Query the FULLTEXT indexes so that each table gets a MATCH_<INDEX>
+	// method for full-text search
+	query = "SELECT s.table_schema, s.table_name, s.index_name, s.column_name" +
+		" FROM information_schema.statistics AS s" +
+		" WHERE s.table_schema IN (?" + strings.Repeat(", ?", len(schemas)-1) + ") AND s.index_type = 'FULLTEXT'" +
+		" ORDER BY s.table_schema, s.table_name, s.index_name, s.seq_in_index"
+	indexRows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, wrap(err)
+	}
+	defer indexRows.Close()
+	for indexRows.Next() {
+		var tableSchema, tableName, indexName, columnName string
+		err := indexRows.Scan(&tableSchema, &tableName, &indexName, &columnName)
+		if err != nil {
+			return tables, err
+		}
+		index, ok := tableIndices[tableSchema+"."+tableName]
+		if !ok {
+			continue
+		}
+		indexes := tables[index].FullTextIndexes
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != String(indexName) {
+			indexes = append(indexes, FullTextIndex{Name: String(indexName)})
+		}
+		indexes[len(indexes)-1].Columns = append(indexes[len(indexes)-1].Columns, String(columnName))
+		tables[index].FullTextIndexes = indexes
+	}
+
 	// Do postprocessing on the tables to fill in the struct names,
 	// constructors, etc
 	tables = processTables(tables)
@@ -298,6 +353,28 @@ func processTables(tables []Table) []Table {
 			fields = append(fields, field)
 		}
 		tables[i].Fields = fields
+		// Only keep the FULLTEXT indexes whose columns were all generated as
+		// StringFields, since MatchAgainst only accepts StringFields
+		fieldTypes := make(map[String]string)
+		for _, field := range fields {
+			fieldTypes[field.Name] = field.Type
+		}
+		var indexes []FullTextIndex
+		for _, index := range tables[i].FullTextIndexes {
+			ok := true
+			for _, column := range index.Columns {
+				if fieldTypes[column] != FieldTypeString {
+					ok = false
+					break
+				}
+			}
+			if !ok {
+				fmt.Printf("Skipping FULLTEXT index %s.%s because not all of its columns are strings\n", tables[i].Name, index.Name)
+				continue
+			}
+			indexes = append(indexes, index)
+		}
+		tables[i].FullTextIndexes = indexes
 	}
 	return tables
 }
@@ -378,7 +455,14 @@ func writeTablesToFile(tables []Table, directory, file, packageName string) erro
 		return err
 	}
 	defer f.Close()
-	t, err := template.New("").Parse(tablesTemplate)
+	t, err := template.New("").Funcs(template.FuncMap{
+		"index_of": func(table Table, index FullTextIndex) interface{} {
+			return struct {
+				Table Table
+				Index FullTextIndex
+			}{table, index}
+		},
+	}).Parse(tablesTemplate)
 	if err != nil {
 		return err
 	}
@@ -82,6 +82,7 @@ func {{$function.Constructor.Export}}_(
 	f := {{$function.StructName.Export}}{FunctionInfo: &sq.FunctionInfo{
 		Schema: "{{$function.Schema}}",
 		Name: "{{$function.Name}}",
+		CaseSensitive: true,
 		Arguments: []interface{}{{"{"}}{{range $i, $arg := $function.Arguments}}{{if not $i}}{{$arg.Name}}{{else}}, {{$arg.Name}}{{end}}{{end}}{{"}"}},
 	},}
 	{{- range $_, $result := $function.Results}}
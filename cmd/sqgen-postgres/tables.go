@@ -15,23 +15,29 @@ import (
 )
 
 const (
-	FieldTypeBoolean = "sq.BooleanField"
-	FieldTypeJSON    = "sq.JSONField"
-	FieldTypeNumber  = "sq.NumberField"
-	FieldTypeString  = "sq.StringField"
-	FieldTypeTime    = "sq.TimeField"
-	FieldTypeEnum    = "sq.EnumField"
-	FieldTypeArray   = "sq.ArrayField"
-	FieldTypeBinary  = "sq.BinaryField"
-
-	FieldConstructorBoolean = "sq.NewBooleanField"
-	FieldConstructorJSON    = "sq.NewJSONField"
-	FieldConstructorNumber  = "sq.NewNumberField"
-	FieldConstructorString  = "sq.NewStringField"
-	FieldConstructorTime    = "sq.NewTimeField"
-	FieldConstructorEnum    = "sq.NewEnumField"
-	FieldConstructorArray   = "sq.NewArrayField"
-	FieldConstructorBinary  = "sq.NewBinaryField"
+	FieldTypeBoolean     = "sq.BooleanField"
+	FieldTypeJSON        = "sq.JSONField"
+	FieldTypeNumber      = "sq.NumberField"
+	FieldTypeString      = "sq.StringField"
+	FieldTypeTime        = "sq.TimeField"
+	FieldTypeEnum        = "sq.EnumField"
+	FieldTypeArray       = "sq.ArrayField"
+	FieldTypeStringArray = "sq.StringArrayField"
+	FieldTypeNumberArray = "sq.NumberArrayField"
+	FieldTypeBinary      = "sq.BinaryField"
+	FieldTypeTSVector    = "sq.TSVectorField"
+
+	FieldConstructorBoolean     = "sq.NewBooleanField"
+	FieldConstructorJSON        = "sq.NewJSONField"
+	FieldConstructorNumber      = "sq.NewNumberField"
+	FieldConstructorString      = "sq.NewStringField"
+	FieldConstructorTime        = "sq.NewTimeField"
+	FieldConstructorEnum        = "sq.NewEnumField"
+	FieldConstructorArray       = "sq.NewArrayField"
+	FieldConstructorStringArray = "sq.NewStringArrayField"
+	FieldConstructorNumberArray = "sq.NewNumberArrayField"
+	FieldConstructorBinary      = "sq.NewBinaryField"
+	FieldConstructorTSVector    = "sq.NewTSVectorField"
 )
 
 var tablesCmd = &cobra.Command{
@@ -64,7 +70,7 @@ import (
 type {{$table.StructName.Export}} struct {
 	*sq.TableInfo
 	{{- range $_, $field := $table.Fields}}
-	{{$field.Name.Export}} {{$field.Type}}
+	{{$field.Name.Export}} {{$field.Type}}{{if $field.ElemType}} // {{$field.ElemType}}[]{{end}}
 	{{- end}}
 }
 {{- end}}
@@ -81,6 +87,7 @@ func {{$table.Constructor.Export}}() {{$table.StructName.Export}} {
 	tbl := {{$table.StructName.Export}}{TableInfo: &sq.TableInfo{
 		Schema: "{{$table.Schema}}",
 		Name: "{{$table.Name}}",
+		CaseSensitive: true,
 	},}
 	{{- range $_, $field := $table.Fields}}
 	tbl.{{$field.Name.Export}} = {{$field.Constructor}}("{{$field.Name}}", tbl.TableInfo)
@@ -116,6 +123,7 @@ type Table struct {
 type TableField struct {
 	Name        String
 	RawType     string
+	ElemType    string // element type of ARRAY columns, e.g. 'text' for text[]
 	Type        string
 	Constructor string
 }
@@ -222,9 +230,13 @@ func getTables(db *sql.DB, databaseURL string, schemas []string) ([]Table, error
 
 	// Prepare the query and args
 	query := replacePlaceholders(
-		"SELECT t.table_type, c.table_schema, c.table_name, c.column_name, c.data_type" +
+		"SELECT t.table_type, c.table_schema, c.table_name, c.column_name, c.data_type, COALESCE(e.data_type, '')" +
 			" FROM information_schema.tables AS t" +
 			" JOIN information_schema.columns AS c USING (table_schema, table_name)" +
+			// element types of ARRAY columns: https://www.postgresql.org/docs/current/infoschema-element-types.html
+			" LEFT JOIN information_schema.element_types AS e" +
+			" ON (c.table_catalog, c.table_schema, c.table_name, 'TABLE', c.dtd_identifier)" +
+			" = (e.object_catalog, e.object_schema, e.object_name, e.object_type, e.collection_type_identifier)" +
 			" WHERE table_schema IN (?" + strings.Repeat(", ?", len(schemas)-1) + ")" +
 			" ORDER BY c.table_schema <> 'public', c.table_schema, t.table_type, c.table_name, c.column_name",
 		// sql custom ordering: https://stackoverflow.com/q/4088532
@@ -244,8 +256,8 @@ func getTables(db *sql.DB, databaseURL string, schemas []string) ([]Table, error
 	var tableIndices = make(map[string]int)
 	var tables []Table
 	for rows.Next() {
-		var tableType, tableSchema, tableName, columnName, columnType string
-		err := rows.Scan(&tableType, &tableSchema, &tableName, &columnName, &columnType)
+		var tableType, tableSchema, tableName, columnName, columnType, elemType string
+		err := rows.Scan(&tableType, &tableSchema, &tableName, &columnName, &columnType, &elemType)
 		if err != nil {
 			return tables, err
 		}
@@ -262,8 +274,9 @@ func getTables(db *sql.DB, databaseURL string, schemas []string) ([]Table, error
 		}
 		// create new field
 		field := TableField{
-			Name:    String(columnName),
-			RawType: columnType,
+			Name:     String(columnName),
+			RawType:  columnType,
+			ElemType: elemType,
 		}
 		index := tableIndices[fullTableName]
 		tables[index].Fields = append(tables[index].Fields, field)
@@ -370,8 +383,18 @@ func (field TableField) fillInTheBlanks() TableField {
 
 	// Array
 	if field.RawType == "ARRAY" {
-		field.Type = FieldTypeArray
-		field.Constructor = FieldConstructorArray
+		elem := TableField{RawType: field.ElemType}.fillInTheBlanks()
+		switch elem.Type {
+		case FieldTypeString:
+			field.Type = FieldTypeStringArray
+			field.Constructor = FieldConstructorStringArray
+		case FieldTypeNumber:
+			field.Type = FieldTypeNumberArray
+			field.Constructor = FieldConstructorNumberArray
+		default:
+			field.Type = FieldTypeArray
+			field.Constructor = FieldConstructorArray
+		}
 		return field
 	}
 
@@ -382,6 +405,13 @@ func (field TableField) fillInTheBlanks() TableField {
 		return field
 	}
 
+	// Full text search
+	if field.RawType == "tsvector" {
+		field.Type = FieldTypeTSVector
+		field.Constructor = FieldConstructorTSVector
+		return field
+	}
+
 	return field
 }
 
@@ -434,7 +464,9 @@ func (table Table) String() string {
 		output += fmt.Sprintf("%s.%s\n", table.Schema, table.Name)
 	}
 	for _, field := range table.Fields {
-		if field.Constructor != "" && field.Type != "" {
+		if field.Constructor != "" && field.Type != "" && field.ElemType != "" {
+			output += fmt.Sprintf("    %s: %s of %s => %s\n", field.Name, field.RawType, field.ElemType, field.Type)
+		} else if field.Constructor != "" && field.Type != "" {
 			output += fmt.Sprintf("    %s: %s => %s\n", field.Name, field.RawType, field.Type)
 		} else {
 			output += fmt.Sprintf("    %s: %s\n", field.Name, field.RawType)
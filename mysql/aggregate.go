@@ -0,0 +1,159 @@
+package sq
+
+import "strings"
+
+// Aggregate represents an aggregate function call i.e.
+// 'NAME([DISTINCT] args [ORDER BY fields] [SEPARATOR separator])'. The
+// aggregate functions in this package wrap an Aggregate in a typed field like
+// NumberAggregate or StringAggregate, so you usually only need to use an
+// Aggregate directly for aggregates that do not have a typed result.
+type Aggregate struct {
+	Alias         string
+	Name          string
+	Args          []interface{}
+	IsDistinct    bool
+	OrderByFields Fields
+	Separator     *string
+}
+
+// AppendSQLExclude marshals the Aggregate into a buffer and an args slice. It
+// propagates the excludedTableQualifiers down to its child elements.
+func (a Aggregate) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
+	buf.WriteString(a.Name)
+	buf.WriteString("(")
+	if a.IsDistinct {
+		buf.WriteString("DISTINCT ")
+	}
+	if len(a.Args) > 0 {
+		format := "?" + strings.Repeat(", ?", len(a.Args)-1)
+		ExpandValues(buf, args, excludedTableQualifiers, format, a.Args)
+	}
+	if len(a.OrderByFields) > 0 {
+		buf.WriteString(" ORDER BY ")
+		a.OrderByFields.AppendSQLExclude(buf, args, excludedTableQualifiers)
+	}
+	if a.Separator != nil {
+		// MySQL only accepts a string literal for the SEPARATOR, so it cannot
+		// be passed in as an argument
+		buf.WriteString(" SEPARATOR '")
+		buf.WriteString(strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(*a.Separator))
+		buf.WriteString("'")
+	}
+	buf.WriteString(")")
+}
+
+// Distinct returns a new Aggregate that only aggregates distinct values i.e.
+// 'NAME(DISTINCT args)'.
+func (a Aggregate) Distinct() Aggregate {
+	a.IsDistinct = true
+	return a
+}
+
+// OrderBy returns a new Aggregate that aggregates its input rows in the given
+// order i.e. 'NAME(args ORDER BY fields)'.
+func (a Aggregate) OrderBy(fields ...Field) Aggregate {
+	a.OrderByFields = fields
+	return a
+}
+
+// As returns a new Aggregate with the new field Alias i.e. 'field AS Alias'.
+func (a Aggregate) As(alias string) Aggregate {
+	a.Alias = alias
+	return a
+}
+
+// GetAlias implements the Field interface. It returns the Alias of the
+// Aggregate.
+func (a Aggregate) GetAlias() string {
+	return a.Alias
+}
+
+// GetName implements the Field interface. It returns the Name of the
+// Aggregate.
+func (a Aggregate) GetName() string {
+	return a.Name
+}
+
+// NumberAggregate is an aggregate function that returns a number. It can be
+// used anywhere a NumberField can.
+type NumberAggregate struct {
+	NumberField
+	aggregate Aggregate
+}
+
+func numberAggregate(aggregate Aggregate) NumberAggregate {
+	return NumberAggregate{
+		NumberField: numberFunction("?", aggregate).As(aggregate.Alias),
+		aggregate:   aggregate,
+	}
+}
+
+// As returns a new NumberAggregate with the new field Alias i.e. 'field AS
+// Alias'.
+func (a NumberAggregate) As(alias string) NumberAggregate {
+	return numberAggregate(a.aggregate.As(alias))
+}
+
+// Distinct returns a new NumberAggregate that only aggregates distinct values
+// i.e. 'NAME(DISTINCT args)'.
+func (a NumberAggregate) Distinct() NumberAggregate {
+	return numberAggregate(a.aggregate.Distinct())
+}
+
+// StringAggregate is an aggregate function that returns a string. It can be
+// used anywhere a StringField can.
+type StringAggregate struct {
+	StringField
+	aggregate Aggregate
+}
+
+func stringAggregate(aggregate Aggregate) StringAggregate {
+	return StringAggregate{
+		StringField: stringFunction("?", aggregate).As(aggregate.Alias),
+		aggregate:   aggregate,
+	}
+}
+
+// As returns a new StringAggregate with the new field Alias i.e. 'field AS
+// Alias'.
+func (a StringAggregate) As(alias string) StringAggregate {
+	return stringAggregate(a.aggregate.As(alias))
+}
+
+// Distinct returns a new StringAggregate that only aggregates distinct values
+// i.e. 'NAME(DISTINCT args)'.
+func (a StringAggregate) Distinct() StringAggregate {
+	return stringAggregate(a.aggregate.Distinct())
+}
+
+// OrderBy returns a new StringAggregate that concatenates its input rows in
+// the given order i.e. 'NAME(args ORDER BY fields)'.
+func (a StringAggregate) OrderBy(fields ...Field) StringAggregate {
+	return stringAggregate(a.aggregate.OrderBy(fields...))
+}
+
+// JSONAggregate is an aggregate function that returns JSON. It can be used
+// anywhere a JSONField can. MySQL does not support DISTINCT or ORDER BY in its
+// JSON aggregate functions.
+type JSONAggregate struct {
+	JSONField
+	aggregate Aggregate
+}
+
+func jsonAggregate(aggregate Aggregate) JSONAggregate {
+	format := "?"
+	return JSONAggregate{
+		JSONField: JSONField{
+			alias:  aggregate.Alias,
+			format: &format,
+			values: []interface{}{aggregate},
+		},
+		aggregate: aggregate,
+	}
+}
+
+// As returns a new JSONAggregate with the new field Alias i.e. 'field AS
+// Alias'.
+func (a JSONAggregate) As(alias string) JSONAggregate {
+	return jsonAggregate(a.aggregate.As(alias))
+}
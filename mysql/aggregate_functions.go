@@ -8,6 +8,23 @@ func Count() NumberField {
 	}
 }
 
+// CountAgg represents the COUNT(*) aggregate function as a NumberAggregate.
+func CountAgg() NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "COUNT",
+		Args: []interface{}{FieldLiteral("*")},
+	})
+}
+
+// CountDistinct represents the COUNT(DISTINCT field) aggregate function.
+func CountDistinct(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name:       "COUNT",
+		Args:       []interface{}{field},
+		IsDistinct: true,
+	})
+}
+
 // CountOver represents the COUNT(*) OVER window function.
 func CountOver(window Window) NumberField {
 	format := "COUNT(*) OVER ?"
@@ -26,6 +43,15 @@ func Sum(field interface{}) NumberField {
 	}
 }
 
+// SumAgg represents the SUM() aggregate function as a
+// NumberAggregate, which supports DISTINCT.
+func SumAgg(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "SUM",
+		Args: []interface{}{field},
+	})
+}
+
 // SumOver represents the SUM() OVER window function.
 func SumOver(field interface{}, window Window) NumberField {
 	format := "SUM(?) OVER ?"
@@ -44,6 +70,15 @@ func Avg(field interface{}) NumberField {
 	}
 }
 
+// AvgAgg represents the AVG() aggregate function as a
+// NumberAggregate, which supports DISTINCT.
+func AvgAgg(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "AVG",
+		Args: []interface{}{field},
+	})
+}
+
 // AvgOver represents the AVG() OVER window function.
 func AvgOver(field interface{}, window Window) NumberField {
 	format := "AVG(?) OVER ?"
@@ -62,6 +97,15 @@ func Min(field interface{}) NumberField {
 	}
 }
 
+// MinAgg represents the MIN() aggregate function as a
+// NumberAggregate, which supports DISTINCT.
+func MinAgg(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "MIN",
+		Args: []interface{}{field},
+	})
+}
+
 // MinOver represents the MIN() OVER window function.
 func MinOver(field interface{}, window Window) NumberField {
 	format := "MIN(?) OVER ?"
@@ -80,6 +124,15 @@ func Max(field interface{}) NumberField {
 	}
 }
 
+// MaxAgg represents the MAX() aggregate function as a
+// NumberAggregate, which supports DISTINCT.
+func MaxAgg(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "MAX",
+		Args: []interface{}{field},
+	})
+}
+
 // MaxOver represents the MAX() OVER window function.
 func MaxOver(field interface{}, window Window) NumberField {
 	format := "MAX(?) OVER ?"
@@ -88,3 +141,68 @@ func MaxOver(field interface{}, window Window) NumberField {
 		values: []interface{}{field, window},
 	}
 }
+
+// GroupConcat represents the GROUP_CONCAT(field SEPARATOR separator) aggregate
+// function, which concatenates the input values into a string. Use OrderBy to
+// control the order of concatenation.
+func GroupConcat(field interface{}, separator string) StringAggregate {
+	return stringAggregate(Aggregate{
+		Name:      "GROUP_CONCAT",
+		Args:      []interface{}{field},
+		Separator: &separator,
+	})
+}
+
+// JSONArrayAgg represents the JSON_ARRAYAGG() aggregate function, which
+// collects the input values into a JSON array.
+func JSONArrayAgg(field interface{}) JSONAggregate {
+	return jsonAggregate(Aggregate{
+		Name: "JSON_ARRAYAGG",
+		Args: []interface{}{field},
+	})
+}
+
+// JSONObjectAgg represents the JSON_OBJECTAGG(key, value) aggregate function,
+// which collects the key/value pairs into a JSON object.
+func JSONObjectAgg(key, value interface{}) JSONAggregate {
+	return jsonAggregate(Aggregate{
+		Name: "JSON_OBJECTAGG",
+		Args: []interface{}{key, value},
+	})
+}
+
+// StddevSamp represents the STDDEV_SAMP() aggregate function, which returns
+// the sample standard deviation of the input values.
+func StddevSamp(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "STDDEV_SAMP",
+		Args: []interface{}{field},
+	})
+}
+
+// StddevPop represents the STDDEV_POP() aggregate function, which returns the
+// population standard deviation of the input values.
+func StddevPop(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "STDDEV_POP",
+		Args: []interface{}{field},
+	})
+}
+
+// VarSamp represents the VAR_SAMP() aggregate function, which returns the
+// sample variance of the input values.
+func VarSamp(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "VAR_SAMP",
+		Args: []interface{}{field},
+	})
+}
+
+// VarPop represents the VAR_POP() aggregate function, which returns the
+// population variance of the input values.
+func VarPop(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "VAR_POP",
+		Args: []interface{}{field},
+	})
+}
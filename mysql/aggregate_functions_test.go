@@ -101,3 +101,43 @@ func TestAggregateFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateFunctions_Extended(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := USERS().As("u")
+	tests := []TT{
+		{"CountDistinct", CountDistinct(u.EMAIL), "COUNT(DISTINCT u.email)", nil},
+		{"Avg Distinct", AvgAgg(u.USER_ID).Distinct(), "AVG(DISTINCT u.user_id)", nil},
+		{"Count arithmetic", Count().AddInt(1), "COUNT(*) + ?", []interface{}{1}},
+		{
+			"GroupConcat",
+			GroupConcat(u.DISPLAYNAME, ", ").Distinct().OrderBy(u.DISPLAYNAME.Desc()),
+			"GROUP_CONCAT(DISTINCT u.displayname ORDER BY u.displayname DESC SEPARATOR ', ')",
+			nil,
+		},
+		{"GroupConcat escaped separator", GroupConcat(u.EMAIL, `'\`), `GROUP_CONCAT(u.email SEPARATOR '''\\')`, nil},
+		{"JSONArrayAgg", JSONArrayAgg(u.EMAIL), "JSON_ARRAYAGG(u.email)", nil},
+		{"JSONObjectAgg", JSONObjectAgg(u.EMAIL, u.USER_ID), "JSON_OBJECTAGG(u.email, u.user_id)", nil},
+		{"StddevSamp", StddevSamp(u.USER_ID), "STDDEV_SAMP(u.user_id)", nil},
+		{"StddevPop", StddevPop(u.USER_ID), "STDDEV_POP(u.user_id)", nil},
+		{"VarSamp", VarSamp(u.USER_ID), "VAR_SAMP(u.user_id)", nil},
+		{"VarPop", VarPop(u.USER_ID), "VAR_POP(u.user_id)", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
@@ -26,6 +26,18 @@ const (
 	ErowsAffected
 )
 
+// DialectVersion is a MySQL server version in the same form as
+// MYSQL_VERSION_ID i.e. major*10000 + minor*100 + patch. The zero value means
+// the oldest supported version.
+type DialectVersion int
+
+// DialectVersions
+const (
+	MySQL57   DialectVersion = 50700
+	MySQL80   DialectVersion = 80000
+	MySQL8019 DialectVersion = 80019
+)
+
 var defaultLogger = log.New(os.Stdout, "[sq] ", log.Ldate|log.Ltime|log.Lshortfile|log.Lmsgprefix)
 
 // BaseQuery is a common query builder that can transform into a SelectQuery,
@@ -98,7 +110,6 @@ func (q BaseQuery) With(CTEs ...CTE) BaseQuery {
 func (q BaseQuery) From(table Table) SelectQuery {
 	return SelectQuery{
 		FromTable: table,
-		Alias:     RandomString(8),
 		CTEs:      q.CTEs,
 		DB:        q.DB,
 		Log:       q.Log,
@@ -110,7 +121,6 @@ func (q BaseQuery) From(table Table) SelectQuery {
 func (q BaseQuery) Select(fields ...Field) SelectQuery {
 	return SelectQuery{
 		SelectFields: fields,
-		Alias:        RandomString(8),
 		CTEs:         q.CTEs,
 		DB:           q.DB,
 		Log:          q.Log,
@@ -122,7 +132,6 @@ func (q BaseQuery) Select(fields ...Field) SelectQuery {
 func (q BaseQuery) SelectOne() SelectQuery {
 	return SelectQuery{
 		SelectFields: Fields{FieldLiteral("1")},
-		Alias:        RandomString(8),
 		CTEs:         q.CTEs,
 		DB:           q.DB,
 		Log:          q.Log,
@@ -134,7 +143,6 @@ func (q BaseQuery) SelectOne() SelectQuery {
 func (q BaseQuery) SelectAll() SelectQuery {
 	return SelectQuery{
 		SelectFields: Fields{FieldLiteral("*")},
-		Alias:        RandomString(8),
 		CTEs:         q.CTEs,
 		DB:           q.DB,
 		Log:          q.Log,
@@ -146,7 +154,6 @@ func (q BaseQuery) SelectAll() SelectQuery {
 func (q BaseQuery) SelectCount() SelectQuery {
 	return SelectQuery{
 		SelectFields: Fields{FieldLiteral("COUNT(*)")},
-		Alias:        RandomString(8),
 		CTEs:         q.CTEs,
 		DB:           q.DB,
 		Log:          q.Log,
@@ -159,7 +166,6 @@ func (q BaseQuery) SelectDistinct(fields ...Field) SelectQuery {
 	return SelectQuery{
 		SelectType:   SelectTypeDistinct,
 		SelectFields: fields,
-		Alias:        RandomString(8),
 		CTEs:         q.CTEs,
 		DB:           q.DB,
 		Log:          q.Log,
@@ -172,7 +178,6 @@ func (q BaseQuery) Selectx(mapper func(*Row), accumulator func()) SelectQuery {
 	return SelectQuery{
 		Mapper:      mapper,
 		Accumulator: accumulator,
-		Alias:       RandomString(8),
 		CTEs:        q.CTEs,
 		DB:          q.DB,
 		Log:         q.Log,
@@ -184,7 +189,6 @@ func (q BaseQuery) Selectx(mapper func(*Row), accumulator func()) SelectQuery {
 func (q BaseQuery) SelectRowx(mapper func(*Row)) SelectQuery {
 	return SelectQuery{
 		Mapper:  mapper,
-		Alias:   RandomString(8),
 		CTEs:    q.CTEs,
 		DB:      q.DB,
 		Log:     q.Log,
@@ -196,7 +200,6 @@ func (q BaseQuery) SelectRowx(mapper func(*Row)) SelectQuery {
 func (q BaseQuery) InsertInto(table BaseTable) InsertQuery {
 	return InsertQuery{
 		IntoTable: table,
-		Alias:     RandomString(8),
 		DB:        q.DB,
 		Log:       q.Log,
 		LogFlag:   q.LogFlag,
@@ -208,7 +211,17 @@ func (q BaseQuery) InsertIgnoreInto(table BaseTable) InsertQuery {
 	return InsertQuery{
 		Ignore:    true,
 		IntoTable: table,
-		Alias:     RandomString(8),
+		DB:        q.DB,
+		Log:       q.Log,
+		LogFlag:   q.LogFlag,
+	}
+}
+
+// ReplaceInto transforms the BaseQuery into an InsertQuery.
+func (q BaseQuery) ReplaceInto(table BaseTable) InsertQuery {
+	return InsertQuery{
+		Replace:   true,
+		IntoTable: table,
 		DB:        q.DB,
 		Log:       q.Log,
 		LogFlag:   q.LogFlag,
@@ -219,7 +232,6 @@ func (q BaseQuery) InsertIgnoreInto(table BaseTable) InsertQuery {
 func (q BaseQuery) Update(table BaseTable) UpdateQuery {
 	return UpdateQuery{
 		UpdateTable: table,
-		Alias:       RandomString(8),
 		CTEs:        q.CTEs,
 		DB:          q.DB,
 		Log:         q.Log,
@@ -231,10 +243,51 @@ func (q BaseQuery) Update(table BaseTable) UpdateQuery {
 func (q BaseQuery) DeleteFrom(tables ...BaseTable) DeleteQuery {
 	return DeleteQuery{
 		FromTables: tables,
-		Alias:      RandomString(8),
 		CTEs:       q.CTEs,
 		DB:         q.DB,
 		Log:        q.Log,
 		LogFlag:    q.LogFlag,
 	}
 }
+
+// variadicQuery transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) variadicQuery(operator VariadicQueryOperator, queries []Query) VariadicQuery {
+	return VariadicQuery{
+		Operator: operator,
+		Queries:  queries,
+		CTEs:     q.CTEs,
+		DB:       q.DB,
+		Log:      q.Log,
+		LogFlag:  q.LogFlag,
+	}
+}
+
+// Union transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) Union(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryUnion, queries)
+}
+
+// UnionAll transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) UnionAll(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryUnionAll, queries)
+}
+
+// Intersect transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) Intersect(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryIntersect, queries)
+}
+
+// IntersectAll transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) IntersectAll(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryIntersectAll, queries)
+}
+
+// Except transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) Except(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryExcept, queries)
+}
+
+// ExceptAll transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) ExceptAll(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryExceptAll, queries)
+}
@@ -2,18 +2,23 @@ package sq
 
 import "strings"
 
-// BooleanField either represents a boolean column or a literal bool value.
+// BooleanField either represents a boolean column, a boolean expression or a
+// literal bool value.
 type BooleanField struct {
 	// BooleanField will be one of the following:
 
-	// 1) Literal bool value
+	// 1) Boolean expression
+	format *string
+	values []interface{}
+
+	// 2) Literal bool value
 	// Examples of literal bool values:
 	// | query | args |
 	// |-------|------|
 	// | ?     | true |
 	value *bool
 
-	// 2) Boolean column
+	// 3) Boolean column
 	// Examples of boolean columns:
 	// | query            | args |
 	// |------------------|------|
@@ -34,39 +39,16 @@ func (f BooleanField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}
 		buf.WriteString("NOT ")
 	}
 	switch {
+	case f.format != nil:
+		// 1) Boolean expression
+		ExpandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
 	case f.value != nil:
-		// 1) Literal bool value
+		// 2) Literal bool value
 		buf.WriteString("?")
 		*args = append(*args, *f.value)
 	default:
-		// 2) Boolean column
-		tableQualifier := f.table.GetAlias()
-		if tableQualifier == "" {
-			tableQualifier = f.table.GetName()
-		}
-		for _, excludedTableQualifier := range excludedTableQualifiers {
-			if tableQualifier == excludedTableQualifier {
-				tableQualifier = ""
-				break
-			}
-		}
-		if tableQualifier != "" {
-			if strings.ContainsAny(tableQualifier, " \t") {
-				buf.WriteString("`")
-				buf.WriteString(tableQualifier)
-				buf.WriteString("`.")
-			} else {
-				buf.WriteString(tableQualifier)
-				buf.WriteString(".")
-			}
-		}
-		if strings.ContainsAny(f.name, " \t") {
-			buf.WriteString("`")
-			buf.WriteString(f.name)
-			buf.WriteString("`")
-		} else {
-			buf.WriteString(f.name)
-		}
+		// 3) Boolean column
+		appendColumn(buf, f.table, f.name, excludedTableQualifiers)
 	}
 	if f.descending != nil {
 		if *f.descending {
@@ -164,6 +146,24 @@ func (f BooleanField) Ne(field BooleanField) Predicate {
 	}
 }
 
+// InQuery returns an 'X IN (subquery)' Predicate. The subquery must select
+// exactly one field.
+func (f BooleanField) InQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? IN (?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// NotInQuery returns an 'X NOT IN (subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f BooleanField) NotInQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? NOT IN (?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
 // String returns the string representation of the BooleanField.
 func (f BooleanField) String() string {
 	buf := &strings.Builder{}
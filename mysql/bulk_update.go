@@ -0,0 +1,152 @@
+package sq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// maxPlaceholders is the maximum number of placeholders MySQL accepts in a
+// single prepared statement.
+const maxPlaceholders = 65535
+
+// bulkAlias is the alias of the VALUES list in a BulkUpdateQuery.
+const bulkAlias = "bulk"
+
+// bulkColumn returns the column of the VALUES list in a BulkUpdateQuery that
+// holds the values of the field.
+func bulkColumn(field Field) CustomField {
+	buf := &strings.Builder{}
+	appendIdentifier(buf, field.GetName(), false)
+	return CustomField{Format: qualify(bulkAlias, buf.String())}
+}
+
+// BulkUpdateQuery updates many rows with different values per row. It is
+// marshalled into one or more 'UPDATE ... JOIN (VALUES ROW(...)) ... SET'
+// queries, where the rows of the VALUES list are joined to the rows of the
+// table on the key fields. Each row of values holds the values of the key
+// fields followed by the values of the value fields. It requires MySQL 8.0.19
+// or later, and because it is a multiple-table UPDATE any ORDER BY or LIMIT in
+// the UpdateQuery is not allowed.
+type BulkUpdateQuery struct {
+	UpdateQuery UpdateQuery
+	KeyFields   Fields
+	ValueFields Fields
+	Rows        [][]interface{}
+	// ChunkSize is the maximum number of rows per query. If zero, it is
+	// derived from the MySQL placeholder limit.
+	ChunkSize int
+}
+
+// BulkUpdate returns a new BulkUpdateQuery that sets the valueFields of the
+// rows matching the keyFields to the values in rows. Any assignments, joins
+// and predicates already in the UpdateQuery are applied to every row.
+func (q UpdateQuery) BulkUpdate(keyFields, valueFields Fields, rows [][]interface{}) BulkUpdateQuery {
+	return BulkUpdateQuery{
+		UpdateQuery: q,
+		KeyFields:   keyFields,
+		ValueFields: valueFields,
+		Rows:        rows,
+	}
+}
+
+// Chunk returns a new BulkUpdateQuery that updates at most size rows per
+// query.
+func (q BulkUpdateQuery) Chunk(size int) BulkUpdateQuery {
+	q.ChunkSize = size
+	return q
+}
+
+// Queries returns the UpdateQueries that the BulkUpdateQuery is executed as,
+// one per chunk of rows. It returns an error if the ChunkSize is not set and
+// the UpdateQuery already uses up too many placeholders for even a single row
+// to fit.
+func (q BulkUpdateQuery) Queries() ([]UpdateQuery, error) {
+	chunkSize := q.ChunkSize
+	if chunkSize <= 0 {
+		buf := &strings.Builder{}
+		var args []interface{}
+		q.UpdateQuery.AppendSQL(buf, &args)
+		chunkSize = maxPlaceholders - len(args)
+		if columns := len(q.KeyFields) + len(q.ValueFields); columns > 0 {
+			chunkSize /= columns
+		}
+		if chunkSize <= 0 {
+			return nil, fmt.Errorf("Cannot bulk update: the UpdateQuery has %d args, which leaves no room for a row under the limit of %d placeholders", len(args), maxPlaceholders)
+		}
+	}
+	// the key and value fields are copied into a new slice, appending
+	// ValueFields to KeyFields could overwrite the backing array of the
+	// caller's KeyFields
+	fields := make(Fields, 0, len(q.KeyFields)+len(q.ValueFields))
+	fields = append(fields, q.KeyFields...)
+	fields = append(fields, q.ValueFields...)
+	var columns []string
+	for _, field := range fields {
+		columns = append(columns, field.GetName())
+	}
+	var predicates []Predicate
+	for _, field := range q.KeyFields {
+		predicates = append(predicates, CustomPredicate{
+			Format: "? = ?",
+			Values: []interface{}{field, bulkColumn(field)},
+		})
+	}
+	var queries []UpdateQuery
+	for start := 0; start < len(q.Rows); start += chunkSize {
+		end := start + chunkSize
+		if end > len(q.Rows) {
+			end = len(q.Rows)
+		}
+		values := NewValuesTable(bulkAlias, columns...)
+		for _, row := range q.Rows[start:end] {
+			values = values.Row(row...)
+		}
+		query := q.UpdateQuery
+		query.JoinTables = append(append(JoinTables{}, query.JoinTables...), JoinTable{
+			JoinType: JoinTypeInner,
+			Table:    values,
+			OnPredicates: VariadicPredicate{
+				Predicates: predicates,
+			},
+		})
+		query.Assignments = append(Assignments{}, query.Assignments...)
+		for _, field := range q.ValueFields {
+			query.Assignments = append(query.Assignments, FieldAssignment{
+				Field: field,
+				Value: bulkColumn(field),
+			})
+		}
+		queries = append(queries, query)
+	}
+	return queries, nil
+}
+
+// Exec will execute the BulkUpdateQuery with the given DB. It returns the
+// total number of rows affected if the ErowsAffected ExecFlag is provided.
+// The queries are executed one after another, so pass in a *sql.Tx if all the
+// chunks should either succeed or fail together.
+func (q BulkUpdateQuery) Exec(db DB, flag ExecFlag) (rowsAffected int64, err error) {
+	q.UpdateQuery.LogSkip += 1
+	return q.ExecContext(nil, db, flag)
+}
+
+// ExecContext will execute the BulkUpdateQuery with the given DB and context.
+// It returns the total number of rows affected if the ErowsAffected ExecFlag
+// is provided. If a chunk fails, the rows affected by the previous chunks are
+// returned alongside the error.
+func (q BulkUpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (rowsAffected int64, err error) {
+	queries, err := q.Queries()
+	if err != nil {
+		return rowsAffected, err
+	}
+	for _, query := range queries {
+		query.LogSkip += 1
+		n, err := query.ExecContext(ctx, db, flag)
+		rowsAffected += n
+		if err != nil {
+			return rowsAffected, err
+		}
+	}
+	return rowsAffected, nil
+}
@@ -0,0 +1,126 @@
+package sq
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestBulkUpdateQuery_Queries(t *testing.T) {
+	type TT struct {
+		description string
+		q           BulkUpdateQuery
+		wantQueries []string
+		wantArgs    [][]interface{}
+	}
+	u := USERS().As("u")
+	rows := [][]interface{}{
+		{1, "alice", "alice@email.com"},
+		{2, "bob", "bob@email.com"},
+		{3, "eve", "eve@email.com"},
+	}
+	tests := []TT{
+		{
+			"single query",
+			Update(u).BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME, u.EMAIL}, rows),
+			[]string{
+				"UPDATE devlab.users AS u" +
+					" JOIN (VALUES ROW(?, ?, ?), ROW(?, ?, ?), ROW(?, ?, ?)) AS bulk (user_id, displayname, email) ON u.user_id = bulk.user_id" +
+					" SET u.displayname = bulk.displayname, u.email = bulk.email",
+			},
+			[][]interface{}{
+				{1, "alice", "alice@email.com", 2, "bob", "bob@email.com", 3, "eve", "eve@email.com"},
+			},
+		},
+		{
+			"chunked with extra predicates",
+			Update(u).
+				Where(u.EMAIL.NeString("")).
+				BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME}, [][]interface{}{{1, "alice"}, {2, "bob"}, {3, "eve"}}).
+				Chunk(2),
+			[]string{
+				"UPDATE devlab.users AS u" +
+					" JOIN (VALUES ROW(?, ?), ROW(?, ?)) AS bulk (user_id, displayname) ON u.user_id = bulk.user_id" +
+					" SET u.displayname = bulk.displayname WHERE u.email <> ?",
+				"UPDATE devlab.users AS u" +
+					" JOIN (VALUES ROW(?, ?)) AS bulk (user_id, displayname) ON u.user_id = bulk.user_id" +
+					" SET u.displayname = bulk.displayname WHERE u.email <> ?",
+			},
+			[][]interface{}{
+				{1, "alice", 2, "bob", ""},
+				{3, "eve", ""},
+			},
+		},
+		func() TT {
+			tbl := &TableInfo{Schema: "devlab", Name: "orders"}
+			id, order := NewNumberField("id", tbl), NewStringField("order", tbl)
+			return TT{
+				"reserved column names",
+				Update(tbl).BulkUpdate(Fields{id}, Fields{order}, [][]interface{}{{1, "a"}}),
+				[]string{
+					"UPDATE devlab.orders JOIN (VALUES ROW(?, ?)) AS bulk (id, `order`) ON orders.id = bulk.id" +
+						" SET orders.`order` = bulk.`order`",
+				},
+				[][]interface{}{{1, "a"}},
+			}
+		}(),
+		{
+			"no rows",
+			Update(u).BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME}, nil),
+			nil,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			var gotQueries []string
+			var gotArgs [][]interface{}
+			queries, err := tt.q.Queries()
+			is.NoErr(err)
+			for _, q := range queries {
+				query, args := q.ToSQL()
+				gotQueries = append(gotQueries, query)
+				gotArgs = append(gotArgs, args)
+			}
+			is.Equal(tt.wantQueries, gotQueries)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestBulkUpdateQuery_ChunkSize(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	rows := make([][]interface{}, 40000)
+	for i := range rows {
+		rows[i] = []interface{}{i, "name"}
+	}
+	queries, err := Update(u).BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME}, rows).Queries()
+	is.NoErr(err)
+	is.Equal(2, len(queries))
+	for _, q := range queries {
+		_, args := q.ToSQL()
+		is.True(len(args) <= maxPlaceholders)
+	}
+
+	// The UpdateQuery leaves no room for any rows
+	ids := make([]int, maxPlaceholders)
+	_, err = Update(u).Where(u.USER_ID.In(ids)).BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME}, rows).Queries()
+	is.True(err != nil)
+	_, err = Update(u).Where(u.USER_ID.In(ids)).BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME}, rows).Exec(nil, 0)
+	is.True(err != nil)
+}
+
+func TestBulkUpdateQuery_Fields(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	// keyFields has spare capacity, which must not be written into
+	fields := Fields{u.USER_ID, u.EMAIL}
+	keyFields := fields[:1]
+	_, err := Update(u).BulkUpdate(keyFields, Fields{u.DISPLAYNAME}, [][]interface{}{{1, "alice"}}).Queries()
+	is.NoErr(err)
+	is.Equal("email", fields[1].GetName())
+}
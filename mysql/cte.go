@@ -12,7 +12,7 @@ type CTE struct {
 
 // AppendSQL marshals the CTE name into a buffer and an args slice.
 func (cte CTE) AppendSQL(buf *strings.Builder, args *[]interface{}) {
-	buf.WriteString(cte.Name)
+	appendIdentifier(buf, cte.Name, false)
 }
 
 // NewCTE creates a new CTE.
@@ -48,10 +48,40 @@ func (cte CTE) GetName() string {
 // Get returns a Field from the CTE, identified by fieldName.
 func (cte CTE) Get(fieldName string) CustomField {
 	return CustomField{
-		Format: cte.Name + "." + fieldName,
+		Format: qualify(cte.Name, fieldName),
 	}
 }
 
+// NumberField returns the NumberField column of the CTE identified by name. The
+// column must appear in the select list of the CTE's query.
+func (cte CTE) NumberField(name string) NumberField {
+	return derivedNumberField(cte, cte.Query, cte.Columns, name)
+}
+
+// StringField returns the StringField column of the CTE identified by name. The
+// column must appear in the select list of the CTE's query.
+func (cte CTE) StringField(name string) StringField {
+	return derivedStringField(cte, cte.Query, cte.Columns, name)
+}
+
+// TimeField returns the TimeField column of the CTE identified by name. The
+// column must appear in the select list of the CTE's query.
+func (cte CTE) TimeField(name string) TimeField {
+	return derivedTimeField(cte, cte.Query, cte.Columns, name)
+}
+
+// BooleanField returns the BooleanField column of the CTE identified by name.
+// The column must appear in the select list of the CTE's query.
+func (cte CTE) BooleanField(name string) BooleanField {
+	return derivedBooleanField(cte, cte.Query, cte.Columns, name)
+}
+
+// JSONField returns the JSONField column of the CTE identified by name. The
+// column must appear in the select list of the CTE's query.
+func (cte CTE) JSONField(name string) JSONField {
+	return derivedJSONField(cte, cte.Query, cte.Columns, name)
+}
+
 // CTEs represents a list of CTEs
 type CTEs []CTE
 
@@ -73,10 +103,10 @@ func (ctes CTEs) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 		if i > 0 {
 			buf.WriteString(", ")
 		}
-		buf.WriteString(cte.Name)
+		appendIdentifier(buf, cte.Name, false)
 		if len(cte.Columns) > 0 {
 			buf.WriteString(" (")
-			buf.WriteString(strings.Join(cte.Columns, ", "))
+			appendIdentifiers(buf, cte.Columns)
 			buf.WriteString(")")
 		}
 		buf.WriteString(" AS (")
@@ -92,22 +122,26 @@ func (ctes CTEs) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 // As aliases the CTE and returns an AliasedCTE.
 func (cte CTE) As(alias string) AliasedCTE {
 	return AliasedCTE{
-		Name:  cte.Name,
-		Alias: alias,
+		Name:    cte.Name,
+		Alias:   alias,
+		Query:   cte.Query,
+		Columns: cte.Columns,
 	}
 }
 
 // AliasedCTE is an aliased version of a CTE derived from a parent CTE.
 type AliasedCTE struct {
-	Name  string
-	Alias string
+	Name    string
+	Alias   string
+	Query   Query
+	Columns []string
 }
 
 // AppendSQL marshals the AliasedCTE name into a buffer and an args slice.
 // There is no need for it to write the alias into the buffer, as the caller of
 // AppendSQL should be responsible for checking for the alias as well.
 func (cte AliasedCTE) AppendSQL(buf *strings.Builder, _ *[]interface{}) {
-	buf.WriteString(cte.Name)
+	appendIdentifier(buf, cte.Name, false)
 }
 
 // GetAlias returns the alias of the AliasedCTE.
@@ -123,6 +157,36 @@ func (cte AliasedCTE) GetName() string {
 // Get returns a Field from the AliasedCTE, identified by fieldName.
 func (cte AliasedCTE) Get(fieldName string) CustomField {
 	return CustomField{
-		Format: cte.Alias + "." + fieldName,
+		Format: qualify(cte.Alias, fieldName),
 	}
 }
+
+// NumberField returns the NumberField column of the AliasedCTE identified by
+// name. The column must appear in the select list of the parent CTE's query.
+func (cte AliasedCTE) NumberField(name string) NumberField {
+	return derivedNumberField(cte, cte.Query, cte.Columns, name)
+}
+
+// StringField returns the StringField column of the AliasedCTE identified by
+// name. The column must appear in the select list of the parent CTE's query.
+func (cte AliasedCTE) StringField(name string) StringField {
+	return derivedStringField(cte, cte.Query, cte.Columns, name)
+}
+
+// TimeField returns the TimeField column of the AliasedCTE identified by name.
+// The column must appear in the select list of the parent CTE's query.
+func (cte AliasedCTE) TimeField(name string) TimeField {
+	return derivedTimeField(cte, cte.Query, cte.Columns, name)
+}
+
+// BooleanField returns the BooleanField column of the AliasedCTE identified by
+// name. The column must appear in the select list of the parent CTE's query.
+func (cte AliasedCTE) BooleanField(name string) BooleanField {
+	return derivedBooleanField(cte, cte.Query, cte.Columns, name)
+}
+
+// JSONField returns the JSONField column of the AliasedCTE identified by name.
+// The column must appear in the select list of the parent CTE's query.
+func (cte AliasedCTE) JSONField(name string) JSONField {
+	return derivedJSONField(cte, cte.Query, cte.Columns, name)
+}
@@ -0,0 +1,196 @@
+package sq
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cursor iterates over the rows of a query one row at a time. It is an
+// alternative to Fetch for when the results should not be accumulated all at
+// once, e.g. when they are streamed to a channel or an HTTP response, or when
+// the iteration may stop early.
+//
+// Each call to Next scans the next row and passes it to the mapper, so the
+// mapper is where the values of the row are read into the caller's variables:
+//
+//	var user User
+//	cur, err := Select().From(u).Cursor(ctx, db, func(row *Row) {
+//		user.UserID = row.Int(u.USER_ID)
+//		user.Email = row.String(u.EMAIL)
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer cur.Close()
+//	for cur.Next() {
+//		fmt.Println(user)
+//	}
+//	if err := cur.Err(); err != nil {
+//		return err
+//	}
+//
+// Unlike Fetch, errors from the mapper (e.g. a failed ScanInto) are not
+// returned by panicking: Next returns false and the error is reported by Err.
+// As with Fetch, the errors are wrapped in a QueryError.
+type Cursor struct {
+	kind     string
+	query    string
+	args     []interface{}
+	row      *Row
+	mapper   func(*Row)
+	err      error
+	closed   bool
+	log      Logger
+	logFlag  LogFlag
+	logBuf   *strings.Builder
+	start    time.Time
+	rowcount int
+}
+
+// openCursor runs the query with the given DB and context and returns a Cursor
+// over its rows. The mapper must have already been called on r to collect the
+// fields of the query. kind is the kind of query reported in a QueryError.
+// As with FetchContext, the query itself is logged by AppendSQL, and the
+// logger and logFlag of the query are used to log the rows and stats when the
+// Cursor is closed.
+func openCursor(ctx context.Context, db DB, kind, query string, args []interface{}, r *Row, mapper func(*Row), logger Logger, logFlag LogFlag) (*Cursor, error) {
+	cur := &Cursor{
+		kind:    kind,
+		query:   query,
+		args:    args,
+		row:     r,
+		mapper:  mapper,
+		log:     logger,
+		logFlag: logFlag,
+		logBuf:  &strings.Builder{},
+		start:   time.Now(),
+	}
+	err := ArgsError(args)
+	if err != nil {
+		return nil, newQueryError(kind, query, args, nil, err)
+	}
+	if ctx == nil {
+		r.rows, err = db.Query(query, args...)
+	} else {
+		r.rows, err = db.QueryContext(ctx, query, args...)
+	}
+	if err != nil {
+		return nil, newQueryError(kind, query, args, nil, err)
+	}
+	return cur, nil
+}
+
+// Next scans the next row and passes it to the mapper. It returns false when
+// there are no more rows or if an error occurred, which can be checked with
+// Err. The Cursor is closed when Next returns false.
+func (cur *Cursor) Next() (ok bool) {
+	if cur == nil || cur.closed || cur.err != nil {
+		return false
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case ExitCode:
+				if v != ExitPeacefully {
+					cur.err = v
+				}
+			case error:
+				cur.err = newQueryError(cur.kind, cur.query, cur.args, cur.row.fields, v)
+			default:
+				cur.err = newQueryError(cur.kind, cur.query, cur.args, cur.row.fields, fmt.Errorf("%#v", r))
+			}
+			ok = false
+		}
+		if !ok {
+			cur.close(2)
+		}
+	}()
+	r := cur.row
+	if !r.rows.Next() {
+		cur.err = newQueryError(cur.kind, cur.query, cur.args, nil, r.rows.Err())
+		return false
+	}
+	cur.rowcount++
+	if len(r.dest) > 0 {
+		if err := r.scan(); err != nil {
+			cur.err = newQueryError(cur.kind, cur.query, cur.args, r.fields, err)
+			return false
+		}
+	}
+	if cur.log != nil && Lresults&cur.logFlag != 0 && cur.rowcount <= 5 {
+		cur.logBuf.WriteString("\n----[ Row ")
+		cur.logBuf.WriteString(strconv.Itoa(cur.rowcount))
+		cur.logBuf.WriteString(" ]----")
+		for i := range r.dest {
+			cur.logBuf.WriteString("\n")
+			cur.logBuf.WriteString(cur.fieldString(i))
+			cur.logBuf.WriteString(": ")
+			AppendSQLDisplay(cur.logBuf, r.dest[i])
+		}
+	}
+	r.index = 0
+	cur.mapper(r)
+	return true
+}
+
+// fieldString returns the i-th field of the row as an SQL string.
+func (cur *Cursor) fieldString(i int) string {
+	buf := &strings.Builder{}
+	var args []interface{}
+	cur.row.fields[i].AppendSQLExclude(buf, &args, nil)
+	return QuestionInterpolate(buf.String(), args...)
+}
+
+// Err returns the error, if any, that was encountered during iteration.
+func (cur *Cursor) Err() error {
+	if cur == nil {
+		return nil
+	}
+	return cur.err
+}
+
+// Close closes the Cursor, preventing further iteration. It is safe to call
+// Close more than once.
+func (cur *Cursor) Close() error {
+	if cur == nil || cur.closed {
+		return nil
+	}
+	return cur.close(1)
+}
+
+// close closes the rows and logs the results and stats of the Cursor if
+// requested. skip is the number of stack frames between close and the caller
+// of the Cursor method.
+func (cur *Cursor) close(skip int) error {
+	if cur.closed {
+		return nil
+	}
+	cur.closed = true
+	err := newQueryError(cur.kind, cur.query, cur.args, nil, cur.row.rows.Close())
+	if cur.log == nil {
+		return err
+	}
+	if Lresults&cur.logFlag != 0 && cur.rowcount > 5 {
+		cur.logBuf.WriteString("\n...")
+	}
+	if Lstats&cur.logFlag != 0 {
+		cur.logBuf.WriteString("\n(Fetched ")
+		cur.logBuf.WriteString(strconv.Itoa(cur.rowcount))
+		cur.logBuf.WriteString(" rows in ")
+		cur.logBuf.WriteString(time.Since(cur.start).String())
+		cur.logBuf.WriteString(")")
+	}
+	if cur.logBuf.Len() > 0 {
+		switch cur.log.(type) {
+		case *log.Logger:
+			cur.log.Output(skip+2, cur.logBuf.String())
+		default:
+			cur.log.Output(skip+1, cur.logBuf.String())
+		}
+	}
+	return err
+}
@@ -42,9 +42,26 @@ func (q DeleteQuery) ToSQL() (string, []interface{}) {
 	buf := &strings.Builder{}
 	var args []interface{}
 	q.AppendSQL(buf, &args)
+	unescapeQuestionMarks(buf)
 	return buf.String(), args
 }
 
+// ToSQLInline marshals the DeleteQuery into a query string with the values inlined
+// as SQL literals instead of bind parameters. It is meant for where bind
+// parameters are not allowed e.g. in DDL, otherwise use ToSQL. It returns
+// an error if a value cannot be written as a literal.
+func (q DeleteQuery) ToSQLInline() (string, error) {
+	q.Log = nil
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args)
+	query, err := questionInterpolate(buf.String(), args)
+	if err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
 // AppendSQL marshals the DeleteQuery into a buffer and args slice.
 func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	// WITH
@@ -67,7 +84,7 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 			}
 			alias := table.GetAlias()
 			if alias != "" {
-				buf.WriteString(alias)
+				appendIdentifier(buf, alias, false)
 			} else {
 				table.AppendSQL(buf, args)
 			}
@@ -76,19 +93,7 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	// USING
 	if q.UsingTable != nil {
 		buf.WriteString(" USING ")
-		switch v := q.UsingTable.(type) {
-		case Query:
-			buf.WriteString("(")
-			v.NestThis().AppendSQL(buf, args)
-			buf.WriteString(")")
-		default:
-			q.UsingTable.AppendSQL(buf, args)
-		}
-		alias := q.UsingTable.GetAlias()
-		if alias != "" {
-			buf.WriteString(" AS ")
-			buf.WriteString(alias)
-		}
+		appendTable(buf, args, q.UsingTable)
 	}
 	// JOIN
 	if len(q.JoinTables) > 0 {
@@ -164,7 +169,6 @@ func (q DeleteQuery) As(alias string) DeleteQuery {
 func DeleteFrom(tables ...BaseTable) DeleteQuery {
 	return DeleteQuery{
 		FromTables: tables,
-		Alias:      RandomString(8),
 	}
 }
 
@@ -314,18 +318,22 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	var tmpargs []interface{}
 	q.LogSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs)
+	unescapeQuestionMarks(tmpbuf)
+	if err = ArgsError(tmpargs); err != nil {
+		return rowsAffected, newQueryError("DELETE", tmpbuf.String(), tmpargs, nil, err)
+	}
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
 		res, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return rowsAffected, err
+		return rowsAffected, newQueryError("DELETE", tmpbuf.String(), tmpargs, nil, err)
 	}
 	if res != nil && ErowsAffected&flag != 0 {
 		rowsAffected, err = res.RowsAffected()
 		if err != nil {
-			return rowsAffected, err
+			return rowsAffected, newQueryError("DELETE", tmpbuf.String(), tmpargs, nil, err)
 		}
 	}
 	return rowsAffected, nil
@@ -0,0 +1,225 @@
+package sq
+
+import (
+	"fmt"
+	"strings"
+)
+
+// This file contains the typed column getters of CTEs and derived tables
+// (subqueries in the FROM clause). The columns are derived from the select
+// list of the query that defines the CTE or derived table. Referencing a
+// column that the query does not produce, or referencing it as the wrong type,
+// writes an error into the SQL query in place of the column.
+
+// queryFields returns the fields produced by the query. It reports false if
+// the fields cannot be determined, e.g. for a CustomQuery.
+func queryFields(query Query) (Fields, bool) {
+	switch q := query.(type) {
+	case SelectQuery:
+		return q.SelectFields, true
+	case VariadicQuery:
+		// the columns of a UNION/INTERSECT/EXCEPT are determined by its
+		// first query
+		if len(q.Queries) > 0 {
+			return queryFields(q.Queries[0])
+		}
+	}
+	return nil, false
+}
+
+// fieldType returns the name of the typed field that the field produces. It
+// returns an empty string if the type is not known, e.g. for a CustomField.
+func fieldType(field Field) string {
+	switch field := field.(type) {
+	case NumberAggregate:
+		// MIN and MAX return the same type as their input
+		switch field.aggregate.Name {
+		case "MIN", "MAX":
+			return ""
+		}
+		return "NumberField"
+	case NumberField:
+		// Min and Max return the same type as their input
+		if field.format != nil && (strings.HasPrefix(*field.format, "MIN(") || strings.HasPrefix(*field.format, "MAX(")) {
+			return ""
+		}
+		return "NumberField"
+	case StringField, StringAggregate:
+		return "StringField"
+	case TimeField:
+		return "TimeField"
+	case JSONField, JSONAggregate:
+		return "JSONField"
+	case BooleanField, CustomPredicate, VariadicPredicate:
+		return "BooleanField"
+	}
+	return ""
+}
+
+// checkColumn checks that the query produces a column called name of type
+// wantType. The columns, if provided, rename the fields of the query
+// positionally (as in 'WITH cte (a, b) AS (...)'). It returns an error if the
+// check fails.
+func checkColumn(source string, query Query, columns []string, name, wantType string) error {
+	fields, ok := queryFields(query)
+	if !ok {
+		for _, column := range columns {
+			if column == name {
+				return nil
+			}
+		}
+		if len(columns) > 0 {
+			return fmt.Errorf("unknown column %s in %s", name, source)
+		}
+		return nil
+	}
+	for i, field := range fields {
+		var fieldName string
+		switch {
+		case i < len(columns):
+			fieldName = columns[i]
+		case field == nil:
+			continue
+		case field.GetAlias() != "":
+			fieldName = field.GetAlias()
+		default:
+			fieldName = field.GetName()
+		}
+		if fieldName != name {
+			continue
+		}
+		if field == nil {
+			return nil
+		}
+		if gotType := fieldType(field); gotType != "" && gotType != wantType {
+			return fmt.Errorf("column %s in %s is a %s, not a %s", name, source, gotType, wantType)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown column %s in %s", name, source)
+}
+
+// derivedSource returns the name that a CTE or derived table is referred to
+// by in error messages.
+func derivedSource(table Table) string {
+	if alias := table.GetAlias(); alias != "" {
+		return alias
+	}
+	return table.GetName()
+}
+
+// derivedColumn returns the query and column list of the CTE or derived
+// table, and the name of the column that the field in the select list of the
+// query is produced as.
+func derivedColumn(table Table, field Field) (query Query, columns []string, name string) {
+	switch t := table.(type) {
+	case CTE:
+		query, columns = t.Query, t.Columns
+	case AliasedCTE:
+		query, columns = t.Query, t.Columns
+	case Query:
+		query = t
+	}
+	name = field.GetAlias()
+	if name == "" {
+		name = field.GetName()
+	}
+	fields, ok := queryFields(query)
+	if !ok {
+		return query, columns, name
+	}
+	for i := 0; i < len(fields) && i < len(columns); i++ {
+		if fields[i] == nil {
+			continue
+		}
+		fieldName := fields[i].GetAlias()
+		if fieldName == "" {
+			fieldName = fields[i].GetName()
+		}
+		if fieldName == name {
+			return query, columns, columns[i]
+		}
+	}
+	return query, columns, name
+}
+
+// NumberColumn returns the column of the CTE or derived table that the field
+// in the select list of its query is produced as. Unlike looking the column up
+// by name with the NumberField method, the column is taken from the field
+// itself:
+//
+//	roleCount := Count().As("role_count")
+//	cte := NewCTE("user_stats", From(ur).GroupBy(ur.USER_ID).Select(ur.USER_ID, roleCount))
+//	NumberColumn(cte, roleCount) // user_stats.role_count
+//
+// The field must appear in the select list of the query.
+func NumberColumn(table Table, field NumberField) NumberField {
+	query, columns, name := derivedColumn(table, field)
+	return derivedNumberField(table, query, columns, name)
+}
+
+// StringColumn returns the column of the CTE or derived table that the field
+// in the select list of its query is produced as, see NumberColumn.
+func StringColumn(table Table, field StringField) StringField {
+	query, columns, name := derivedColumn(table, field)
+	return derivedStringField(table, query, columns, name)
+}
+
+// TimeColumn returns the column of the CTE or derived table that the field
+// in the select list of its query is produced as, see NumberColumn.
+func TimeColumn(table Table, field TimeField) TimeField {
+	query, columns, name := derivedColumn(table, field)
+	return derivedTimeField(table, query, columns, name)
+}
+
+// BooleanColumn returns the column of the CTE or derived table that the field
+// in the select list of its query is produced as, see NumberColumn.
+func BooleanColumn(table Table, field BooleanField) BooleanField {
+	query, columns, name := derivedColumn(table, field)
+	return derivedBooleanField(table, query, columns, name)
+}
+
+// JSONColumn returns the column of the CTE or derived table that the field
+// in the select list of its query is produced as, see NumberColumn.
+func JSONColumn(table Table, field JSONField) JSONField {
+	query, columns, name := derivedColumn(table, field)
+	return derivedJSONField(table, query, columns, name)
+}
+
+func derivedNumberField(table Table, query Query, columns []string, name string) NumberField {
+	if err := checkColumn(derivedSource(table), query, columns, name, "NumberField"); err != nil {
+		return numberFunction("?", &BuildError{Err: err})
+	}
+	return NewNumberField(name, table)
+}
+
+func derivedStringField(table Table, query Query, columns []string, name string) StringField {
+	if err := checkColumn(derivedSource(table), query, columns, name, "StringField"); err != nil {
+		return stringFunction("?", &BuildError{Err: err})
+	}
+	return NewStringField(name, table)
+}
+
+func derivedTimeField(table Table, query Query, columns []string, name string) TimeField {
+	if err := checkColumn(derivedSource(table), query, columns, name, "TimeField"); err != nil {
+		format := "?"
+		return TimeField{format: &format, values: []interface{}{&BuildError{Err: err}}}
+	}
+	return NewTimeField(name, table)
+}
+
+func derivedBooleanField(table Table, query Query, columns []string, name string) BooleanField {
+	if err := checkColumn(derivedSource(table), query, columns, name, "BooleanField"); err != nil {
+		format := "?"
+		return BooleanField{format: &format, values: []interface{}{&BuildError{Err: err}}}
+	}
+	return NewBooleanField(name, table)
+}
+
+func derivedJSONField(table Table, query Query, columns []string, name string) JSONField {
+	if err := checkColumn(derivedSource(table), query, columns, name, "JSONField"); err != nil {
+		format := "?"
+		return JSONField{format: &format, values: []interface{}{&BuildError{Err: err}}}
+	}
+	return NewJSONField(name, table)
+}
@@ -0,0 +1,79 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestDerivedColumns(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		wantQuery   string
+		wantErr     string
+	}
+	u, ur := USERS().As("u"), USER_ROLES().As("ur")
+	cte := NewCTE("user_stats", From(u).
+		Join(ur, ur.USER_ID.Eq(u.USER_ID)).
+		GroupBy(u.USER_ID, u.DISPLAYNAME).
+		Select(u.USER_ID, u.DISPLAYNAME, Count().As("role_count"), Max(ur.CREATED_AT).As("last_role_at"), Predicatef("? IS NULL", ur.DELETED_AT).As("active")),
+	)
+	renamed := NewCTE("renamed", From(u).Select(u.USER_ID, u.EMAIL), "id", "mail")
+	derived := From(u).Select(u.USER_ID, GroupConcat(u.EMAIL, ",").As("emails"), JSONArrayAgg(u.EMAIL).As("json")).As("d")
+	custom := NewCTE("custom", Queryf("SELECT 1"))
+	tests := []TT{
+		{"CTE NumberField", cte.NumberField("user_id"), "user_stats.user_id", ""},
+		{"CTE StringField", cte.StringField("displayname"), "user_stats.displayname", ""},
+		{"CTE aliased aggregate", cte.NumberField("role_count"), "user_stats.role_count", ""},
+		{"CTE TimeField", cte.TimeField("last_role_at"), "user_stats.last_role_at", ""},
+		{"CTE BooleanField", cte.BooleanField("active"), "user_stats.active", ""},
+		{"AliasedCTE", cte.As("s").StringField("displayname"), "s.displayname", ""},
+		{"CTE unknown column", cte.NumberField("email"), "?", "unknown column email in user_stats"},
+		{"CTE wrong type", cte.StringField("user_id"), "?", "column user_id in user_stats is a NumberField, not a StringField"},
+		{"CTE column list", renamed.StringField("mail"), "renamed.mail", ""},
+		{"CTE column list unknown", renamed.StringField("email"), "?", "unknown column email in renamed"},
+		{"derived table", derived.NumberField("user_id"), "d.user_id", ""},
+		{"derived StringField", derived.StringField("emails"), "d.emails", ""},
+		{"derived JSONField", derived.JSONField("json"), "d.json", ""},
+		{"derived table wrong type", derived.NumberField("emails"), "?", "column emails in d is a StringField, not a NumberField"},
+		{"VariadicQuery", Union(derived, derived).As("v").NumberField("user_id"), "v.user_id", ""},
+		{"unverifiable query", custom.NumberField("anything"), "custom.anything", ""},
+		{"NumberColumn", NumberColumn(cte, Count().As("role_count")), "user_stats.role_count", ""},
+		{"NumberColumn AliasedCTE", NumberColumn(cte.As("s"), u.USER_ID), "s.user_id", ""},
+		{"StringColumn column list", StringColumn(renamed, u.EMAIL), "renamed.mail", ""},
+		{"StringColumn derived table", StringColumn(derived, u.DISPLAYNAME), "?", "unknown column displayname in d"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil)
+			is.Equal(tt.wantQuery, buf.String())
+			if tt.wantErr == "" {
+				is.Equal(0, len(args))
+				return
+			}
+			err := ArgsError(args)
+			is.True(err != nil)
+			is.Equal(tt.wantErr, err.Error())
+		})
+	}
+}
+
+func TestDerivedColumns_Query(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	cte := NewCTE("lead_users", From(u).Where(u.EMAIL.LikeString("%@lead.com")).Select(u.USER_ID, u.DISPLAYNAME))
+	q := From(cte).With(cte).
+		Where(cte.NumberField("user_id").GtInt(10)).
+		Select(cte.StringField("displayname"))
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal("WITH lead_users AS (SELECT u.user_id, u.displayname FROM devlab.users AS u WHERE u.email LIKE ?)"+
+		" SELECT lead_users.displayname FROM lead_users WHERE lead_users.user_id > ?", gotQuery)
+	is.Equal([]interface{}{"%@lead.com", 10}, gotArgs)
+}
@@ -0,0 +1,198 @@
+package sq
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/matryer/is"
+)
+
+func TestQueryError(t *testing.T) {
+	type TT struct {
+		description string
+		mapper      func(*Row)
+		wantIndex   int
+		wantField   string
+	}
+	tbl := &TableInfo{Name: "wide"}
+	id, id2, data := NewNumberField("id", tbl), NewNumberField("id2", tbl), NewJSONField("json0", tbl)
+	tests := []TT{
+		{
+			description: "rows.Scan error",
+			mapper: func(row *Row) {
+				var createdAt time.Time
+				row.Int(id)
+				row.ScanInto(&createdAt, id2)
+			},
+			wantIndex: 1,
+			wantField: "id2",
+		},
+		{
+			description: "Row getter overflow",
+			mapper: func(row *Row) {
+				row.Int(id)
+				row.Int8(id2)
+			},
+			wantIndex: 1,
+			wantField: "id2",
+		},
+		{
+			description: "Row ScanInto overflow",
+			mapper: func(row *Row) {
+				var n int8
+				row.ScanInto(&n, id)
+				row.Int(id2)
+			},
+			wantIndex: 0,
+			wantField: "id",
+		},
+		{
+			description: "row.JSON unmarshal error",
+			mapper: func(row *Row) {
+				var n int
+				row.Int(id)
+				row.Int(id2)
+				row.JSON(data, &n)
+			},
+			wantIndex: 2,
+			wantField: "json0",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			db, err := sql.Open("widerows", "200")
+			is.NoErr(err)
+			defer db.Close()
+			err = Selectx(tt.mapper, func() {}).From(tbl).Fetch(db)
+			var qerr *QueryError
+			is.True(errors.As(err, &qerr))
+			is.Equal("SELECT", qerr.Kind)
+			is.True(strings.HasPrefix(qerr.Query, "SELECT wide.id"))
+			is.Equal(tt.wantIndex, qerr.Index)
+			is.True(qerr.Field != nil)
+			is.Equal(tt.wantField, qerr.Field.GetName())
+			is.True(errors.Unwrap(err) != nil)
+			is.True(strings.Contains(err.Error(), "failed to scan field"))
+
+			// Cursor reports the same error through Err
+			cur, err := Selectx(tt.mapper, nil).From(tbl).Cursor(nil, db, nil)
+			is.NoErr(err)
+			for cur.Next() {
+			}
+			qerr = nil
+			is.True(errors.As(cur.Err(), &qerr))
+			is.Equal(tt.wantIndex, qerr.Index)
+		})
+	}
+}
+
+func TestQueryError_NotScanError(t *testing.T) {
+	is := is.New(t)
+	is.Equal(nil, newQueryError("SELECT", "", nil, nil, nil))
+	is.Equal(sql.ErrNoRows, newQueryError("SELECT", "", nil, nil, sql.ErrNoRows))
+	err := newQueryError("UPDATE", "UPDATE t SET a = ?", []interface{}{1}, nil, sql.ErrConnDone)
+	var qerr *QueryError
+	is.True(errors.As(err, &qerr))
+	is.Equal(-1, qerr.Index)
+	is.Equal(nil, qerr.Field)
+	is.Equal([]interface{}{1}, qerr.Args)
+	is.True(errors.Is(err, sql.ErrConnDone))
+	is.Equal("UPDATE query failed: "+sql.ErrConnDone.Error(), err.Error())
+}
+
+func TestQueryError_FirstMapperCall(t *testing.T) {
+	is := is.New(t)
+	db, err := sql.Open("widerows", "1")
+	is.NoErr(err)
+	defer db.Close()
+	tbl := &TableInfo{Name: "wide"}
+	id := NewNumberField("id", tbl)
+	errMapper := errors.New("mapper failed")
+	var calls int
+	mapper := func(row *Row) {
+		calls++
+		if calls == 1 {
+			panic(errMapper)
+		}
+		row.Int(id)
+	}
+	// the query has not been built yet, so the error is not a QueryError
+	err = Selectx(mapper, func() {}).From(tbl).Fetch(db)
+	var qerr *QueryError
+	is.True(!errors.As(err, &qerr))
+	is.Equal(errMapper, err)
+}
+
+func TestBuildError(t *testing.T) {
+	is := is.New(t)
+	db, err := sql.Open("widerows", "1")
+	is.NoErr(err)
+	defer db.Close()
+	tbl := &TableInfo{Name: "wide"}
+	id := NewNumberField("id", tbl)
+	q := Selectx(func(row *Row) { row.Int(id) }, func() {}).From(tbl).GroupBy(Grouping())
+	_, args := q.ToSQL()
+	var berr *BuildError
+	is.True(errors.As(ArgsError(args), &berr))
+	is.Equal("GROUPING needs at least one field", berr.Error())
+
+	// Fetch and Cursor return the BuildError without running the query
+	err = q.Fetch(db)
+	var qerr *QueryError
+	is.True(errors.As(err, &qerr))
+	is.True(errors.As(err, &berr))
+	_, err = q.Cursor(nil, db, nil)
+	is.True(errors.As(err, &berr))
+
+	// running the query anyway fails with the BuildError
+	query, args := q.ToSQL()
+	_, err = db.Query(query, args...)
+	is.True(errors.As(err, &berr))
+}
+
+func TestIsViolation(t *testing.T) {
+	type TT struct {
+		description string
+		err         error
+		is          func(error) bool
+		want        bool
+	}
+	wrap := func(err error) error {
+		return fmt.Errorf("creating user: %w", newQueryError("INSERT", "", nil, nil, err))
+	}
+	unique := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'bob@example.com' for key 'users_email_key'"}
+	unique80 := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'bob@example.com' for key 'users.users_email_key'"}
+	tests := []TT{
+		{"unique", wrap(unique), func(err error) bool { return IsUniqueViolation(err, "") }, true},
+		{"unique constraint", wrap(unique), func(err error) bool { return IsUniqueViolation(err, "users_email_key") }, true},
+		{"unique constraint qualified with table", wrap(unique80), func(err error) bool { return IsUniqueViolation(err, "users_email_key") }, true},
+		{"other unique constraint", wrap(unique), func(err error) bool { return IsUniqueViolation(err, "email_key") }, false},
+		{"not unique", wrap(&mysql.MySQLError{Number: 1452}), func(err error) bool { return IsUniqueViolation(err, "") }, false},
+		{"foreign key parent", wrap(&mysql.MySQLError{Number: 1451}), IsForeignKeyViolation, true},
+		{"foreign key child", wrap(&mysql.MySQLError{Number: 1452}), IsForeignKeyViolation, true},
+		{"not null", wrap(&mysql.MySQLError{Number: 1048}), IsNotNullViolation, true},
+		{"no default", wrap(&mysql.MySQLError{Number: 1364}), IsNotNullViolation, true},
+		{"check", wrap(&mysql.MySQLError{Number: 3819}), IsCheckViolation, true},
+		{"serialization failure", wrap(&mysql.MySQLError{Number: 1213}), IsSerializationFailure, true},
+		{"deadlock", wrap(&mysql.MySQLError{Number: 1213}), IsDeadlock, true},
+		{"lock wait timeout is not a deadlock", wrap(&mysql.MySQLError{Number: 1205}), IsDeadlock, false},
+		{"not a MySQLError", wrap(sql.ErrConnDone), IsDeadlock, false},
+		{"nil", nil, IsForeignKeyViolation, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			is.Equal(tt.want, tt.is(tt.err))
+		})
+	}
+}
@@ -55,7 +55,7 @@ func (fs Fields) AppendSQLExcludeWithAlias(buf *strings.Builder, args *[]interfa
 			field.AppendSQLExclude(buf, args, excludedTableQualifiers)
 			if alias = field.GetAlias(); alias != "" {
 				buf.WriteString(" AS ")
-				buf.WriteString(alias)
+				appendIdentifier(buf, alias, false)
 			}
 		}
 	}
@@ -0,0 +1,393 @@
+package filter
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sq "github.com/bokwoon95/go-structured-query/mysql"
+)
+
+// Operators that a Filter can have. Not every operator is supported by every
+// kind of field, see the Field constructors.
+const (
+	OpEq    = "eq"   // field = value
+	OpNe    = "ne"   // field <> value
+	OpGt    = "gt"   // field > value
+	OpGe    = "ge"   // field >= value
+	OpLt    = "lt"   // field < value
+	OpLe    = "le"   // field <= value
+	OpIn    = "in"   // field IN (value1, value2, ...)
+	OpNotIn = "nin"  // field NOT IN (value1, value2, ...)
+	OpLike  = "like" // field LIKE value
+	OpNull  = "null" // field IS NULL if value is true, field IS NOT NULL if false
+)
+
+// Filter is a condition on a field e.g. status=eq:active is the Filter
+// {Name: "status", Operator: "eq", Value: "active"}.
+type Filter struct {
+	Name     string
+	Operator string
+	Value    string
+}
+
+// Sort is an ordering by a field e.g. sort=-name is the Sort {Name: "name",
+// Desc: true}.
+type Sort struct {
+	Name string
+	Desc bool
+}
+
+// Spec is a parsed filter spec, i.e. the filters and sorts requested by the
+// client. The names in it are not trusted until they are looked up in an
+// Allowlist by Build.
+type Spec struct {
+	Filters []Filter
+	Sorts   []Sort
+}
+
+// SortParam is the query parameter that ParseQuery reads the Sorts from.
+const SortParam = "sort"
+
+// ParseQuery parses the query parameters of a URL into a Spec. Each parameter
+// is a filter on the field of the same name, in the form operator:value e.g.
+// ?status=eq:active&created_at=gt:2024-01-01. A parameter without a colon, or
+// whose part before the first colon is not a word (e.g. a timestamp), is the
+// value of an eq filter e.g. ?status=active. A word that is not an operator
+// (e.g. ?score=gte:5) is an *Error, so values that start with a word and a
+// colon must be given an operator e.g. ?name=eq:bob:smith. The sort parameter is a comma separated list of field
+// names, where names prefixed with - are sorted in descending order e.g.
+// ?sort=-created_at,name.
+//
+// Parameters in ignore (such as the ones used for pagination) are skipped.
+func ParseQuery(query url.Values, ignore ...string) (Spec, error) {
+	var spec Spec
+	skip := make(map[string]bool)
+	for _, name := range ignore {
+		skip[name] = true
+	}
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	// sort the names so that the order of the predicates does not depend on
+	// the order of map iteration
+	sort.Strings(names)
+	for _, name := range names {
+		if skip[name] {
+			continue
+		}
+		for _, value := range query[name] {
+			if name == SortParam {
+				for _, s := range strings.Split(value, ",") {
+					if s == "" || s == "-" {
+						return spec, &Error{Param: SortParam, Name: s, Reason: "empty field name"}
+					}
+					if strings.HasPrefix(s, "-") {
+						spec.Sorts = append(spec.Sorts, Sort{Name: s[1:], Desc: true})
+					} else {
+						spec.Sorts = append(spec.Sorts, Sort{Name: s})
+					}
+				}
+				continue
+			}
+			filter := Filter{Name: name, Operator: OpEq, Value: value}
+			if i := strings.Index(value, ":"); i >= 0 && isWord(value[:i]) {
+				if !operators[value[:i]] {
+					return spec, &Error{
+						Param:  name,
+						Name:   name,
+						Reason: "unknown operator " + strconv.Quote(value[:i]) + ", expected one of " + strings.Join(operatorNames, ", "),
+					}
+				}
+				filter.Operator, filter.Value = value[:i], value[i+1:]
+			}
+			spec.Filters = append(spec.Filters, filter)
+		}
+	}
+	return spec, nil
+}
+
+var operators = map[string]bool{
+	OpEq: true, OpNe: true, OpGt: true, OpGe: true, OpLt: true, OpLe: true,
+	OpIn: true, OpNotIn: true, OpLike: true, OpNull: true,
+}
+
+var operatorNames = []string{OpEq, OpNe, OpGt, OpGe, OpLt, OpLe, OpIn, OpNotIn, OpLike, OpNull}
+
+// isWord reports whether s is made up of one or more ASCII letters only.
+func isWord(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// Error is the error returned for a Filter or Sort that is not allowed or
+// cannot be parsed. Its message does not contain anything but what the client
+// sent and the names in the Allowlist, so that it can be returned as is in a
+// 400 Bad Request.
+type Error struct {
+	Param  string // the query parameter i.e. the field name, or "sort"
+	Name   string // the field name
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Param == SortParam {
+		return "invalid sort " + strconv.Quote(e.Name) + ": " + e.Reason
+	}
+	return "invalid filter " + strconv.Quote(e.Name) + ": " + e.Reason
+}
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindEnum
+	kindNumber
+	kindTime
+	kindBoolean
+)
+
+// Field is a field that can be filtered and sorted on, created by one of the
+// String, Enum, Number, Time or Boolean constructors.
+type Field struct {
+	kind      kind
+	field     sq.Field
+	desc      sq.Field
+	enums     map[string]bool
+	operators []string
+}
+
+// String returns a Field for a StringField that supports the eq, ne, gt, ge,
+// lt, le, in, nin, like and null operators.
+func String(field sq.StringField) Field {
+	return Field{
+		kind:      kindString,
+		field:     field,
+		desc:      field.Desc(),
+		operators: []string{OpEq, OpNe, OpGt, OpGe, OpLt, OpLe, OpIn, OpNotIn, OpLike, OpNull},
+	}
+}
+
+// Enum returns a Field for an EnumField that supports the eq, ne, in, nin and
+// null operators. Only the given values are accepted.
+func Enum(field sq.EnumField, values ...string) Field {
+	enums := make(map[string]bool)
+	for _, value := range values {
+		enums[value] = true
+	}
+	return Field{
+		kind:      kindEnum,
+		field:     field,
+		desc:      field.Desc(),
+		enums:     enums,
+		operators: []string{OpEq, OpNe, OpIn, OpNotIn, OpNull},
+	}
+}
+
+// Number returns a Field for a NumberField that supports the eq, ne, gt, ge,
+// lt, le, in, nin and null operators.
+func Number(field sq.NumberField) Field {
+	return Field{
+		kind:      kindNumber,
+		field:     field,
+		desc:      field.Desc(),
+		operators: []string{OpEq, OpNe, OpGt, OpGe, OpLt, OpLe, OpIn, OpNotIn, OpNull},
+	}
+}
+
+// Time returns a Field for a TimeField that supports the eq, ne, gt, ge, lt,
+// le and null operators. Values are either RFC 3339 timestamps or dates in
+// the form 2006-01-02 (taken as midnight UTC).
+func Time(field sq.TimeField) Field {
+	return Field{
+		kind:      kindTime,
+		field:     field,
+		desc:      field.Desc(),
+		operators: []string{OpEq, OpNe, OpGt, OpGe, OpLt, OpLe, OpNull},
+	}
+}
+
+// Boolean returns a Field for a BooleanField that supports the eq, ne and null
+// operators.
+func Boolean(field sq.BooleanField) Field {
+	return Field{
+		kind:      kindBoolean,
+		field:     field,
+		desc:      field.Desc(),
+		operators: []string{OpEq, OpNe, OpNull},
+	}
+}
+
+// Allowlist maps the names that clients may filter and sort on to the Fields
+// they stand for. Names that are not in the Allowlist are rejected, so only
+// the Fields in it can ever reach the SQL query.
+type Allowlist map[string]Field
+
+// Build validates the Spec against the Allowlist and returns the predicate of
+// its Filters (ANDed together) and the fields of its Sorts. Every Filter
+// value is parsed according to the type of its field and passed to the query
+// as an argument, never as SQL. It returns an *Error if a name is not in the
+// Allowlist, an operator is not supported by its field, or a value cannot be
+// parsed.
+//
+//	allowlist := filter.Allowlist{
+//		"status":     filter.Enum(u.STATUS, "active", "suspended"),
+//		"created_at": filter.Time(u.CREATED_AT),
+//		"name":       filter.String(u.NAME),
+//	}
+//	spec, err := filter.ParseQuery(r.URL.Query(), "cursor")
+//	if err != nil {
+//		http.Error(w, err.Error(), http.StatusBadRequest)
+//		return
+//	}
+//	predicate, orderBy, err := allowlist.Build(spec)
+//	if err != nil {
+//		http.Error(w, err.Error(), http.StatusBadRequest)
+//		return
+//	}
+//	query := sq.From(u).Where(predicate.Predicates...).OrderBy(orderBy...)
+func (a Allowlist) Build(spec Spec) (sq.VariadicPredicate, []sq.Field, error) {
+	predicate := sq.And()
+	for _, filter := range spec.Filters {
+		field, ok := a[filter.Name]
+		if !ok {
+			return predicate, nil, &Error{Param: filter.Name, Name: filter.Name, Reason: "unknown field"}
+		}
+		p, err := field.predicate(filter.Operator, filter.Value)
+		if err != nil {
+			return predicate, nil, &Error{Param: filter.Name, Name: filter.Name, Reason: err.Error()}
+		}
+		predicate.Predicates = append(predicate.Predicates, p)
+	}
+	var orderBy []sq.Field
+	sorted := make(map[string]bool)
+	for _, s := range spec.Sorts {
+		field, ok := a[s.Name]
+		if !ok {
+			return predicate, nil, &Error{Param: SortParam, Name: s.Name, Reason: "unknown field"}
+		}
+		if sorted[s.Name] {
+			return predicate, nil, &Error{Param: SortParam, Name: s.Name, Reason: "sorted more than once"}
+		}
+		sorted[s.Name] = true
+		if s.Desc {
+			orderBy = append(orderBy, field.desc)
+		} else {
+			orderBy = append(orderBy, field.field)
+		}
+	}
+	return predicate, orderBy, nil
+}
+
+// predicate returns the predicate of the operator and the value on the
+// Field. The error is the reason to be put in an *Error.
+func (f Field) predicate(operator, value string) (sq.Predicate, error) {
+	supported := false
+	for _, op := range f.operators {
+		if op == operator {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, errors.New("operator " + strconv.Quote(operator) + " is not supported, expected one of " + strings.Join(f.operators, ", "))
+	}
+	switch operator {
+	case OpNull:
+		isNull, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.New("null expects true or false, got " + strconv.Quote(value))
+		}
+		if isNull {
+			return sq.Predicatef("? IS NULL", f.field), nil
+		}
+		return sq.Predicatef("? IS NOT NULL", f.field), nil
+	case OpIn, OpNotIn:
+		var values []interface{}
+		for _, s := range strings.Split(value, ",") {
+			v, err := f.parse(s)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		if operator == OpNotIn {
+			return sq.Predicatef("? NOT IN (?)", f.field, values), nil
+		}
+		return sq.Predicatef("? IN (?)", f.field, values), nil
+	}
+	v, err := f.parse(value)
+	if err != nil {
+		return nil, err
+	}
+	switch operator {
+	case OpNe:
+		return sq.Predicatef("? <> ?", f.field, v), nil
+	case OpGt:
+		return sq.Predicatef("? > ?", f.field, v), nil
+	case OpGe:
+		return sq.Predicatef("? >= ?", f.field, v), nil
+	case OpLt:
+		return sq.Predicatef("? < ?", f.field, v), nil
+	case OpLe:
+		return sq.Predicatef("? <= ?", f.field, v), nil
+	case OpLike:
+		return sq.Predicatef("? LIKE ?", f.field, v), nil
+	default:
+		return sq.Predicatef("? = ?", f.field, v), nil
+	}
+}
+
+// parse parses the value according to the kind of the Field.
+func (f Field) parse(value string) (interface{}, error) {
+	switch f.kind {
+	case kindEnum:
+		if !f.enums[value] {
+			return nil, errors.New(strconv.Quote(value) + " is not one of the allowed values")
+		}
+		return value, nil
+	case kindNumber:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n, nil
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errors.New(strconv.Quote(value) + " is not a number")
+		}
+		// ParseFloat accepts NaN and Inf, which are not valid filter values
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, errors.New(strconv.Quote(value) + " is not a finite number")
+		}
+		return n, nil
+	case kindTime:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, errors.New(strconv.Quote(value) + " is not an RFC 3339 timestamp or a date in the form 2006-01-02")
+		}
+		return t, nil
+	case kindBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.New(strconv.Quote(value) + " is not true or false")
+		}
+		return b, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,55 @@
+package sq
+
+import (
+	"errors"
+	"strings"
+)
+
+// RollupFields represents the fields of a GROUP BY ... WITH ROLLUP clause. It
+// is created with the Rollup function and passed to SelectQuery.GroupBy like
+// any other Field. MySQL only supports WITH ROLLUP over the whole GROUP BY
+// clause, so RollupFields must be the only GROUP BY element. MySQL does not
+// support CUBE or GROUPING SETS.
+type RollupFields Fields
+
+// AppendSQLExclude marshals the RollupFields into a buffer and an args slice.
+// It is only called when the RollupFields is not the only GROUP BY element,
+// which MySQL cannot express, so it writes a BuildError instead. The
+// SelectQuery renders a valid RollupFields itself.
+func (r RollupFields) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
+	appendBuildError(buf, args, errors.New("unsupported grouping: MySQL only supports WITH ROLLUP when Rollup is the only GROUP BY element"))
+}
+
+// Rollup returns a RollupFields, which is rendered as 'GROUP BY fields... WITH
+// ROLLUP'. It groups by each prefix of the fields i.e. (a, b, c), (a, b), (a)
+// and ().
+func Rollup(fields ...Field) RollupFields {
+	return RollupFields(fields)
+}
+
+// GetAlias implements the Field interface. It always returns an empty string
+// because RollupFields do not have aliases.
+func (r RollupFields) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Field interface. It always returns an empty string
+// because RollupFields do not have names.
+func (r RollupFields) GetName() string {
+	return ""
+}
+
+// Grouping represents the GROUPING(fields...) function, which returns a bit
+// mask indicating which of the fields are not part of the grouping set of the
+// current row. It is used to tell the subtotal rows produced by WITH ROLLUP
+// apart from regular rows. It requires MySQL 8.0 and above.
+func Grouping(fields ...Field) NumberField {
+	if len(fields) == 0 {
+		return numberFunction("GROUPING(?)", &BuildError{Err: errors.New("GROUPING needs at least one field")})
+	}
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		values[i] = field
+	}
+	return numberFunction("GROUPING(?"+strings.Repeat(", ?", len(fields)-1)+")", values...)
+}
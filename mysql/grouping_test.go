@@ -0,0 +1,63 @@
+package sq
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRollupFields(t *testing.T) {
+	type TT struct {
+		description string
+		q           SelectQuery
+		wantQuery   string
+		wantErr     string
+	}
+	ur := USER_ROLES().As("ur")
+	base := From(ur).Select(ur.ROLE, ur.COHORT, Grouping(ur.ROLE, ur.COHORT), Count())
+	tests := []TT{
+		{
+			"Rollup",
+			base.GroupBy(Rollup(ur.ROLE, ur.COHORT)),
+			"SELECT ur.role, ur.cohort, GROUPING(ur.role, ur.cohort), COUNT(*) FROM devlab.user_roles AS ur" +
+				" GROUP BY ur.role, ur.cohort WITH ROLLUP",
+			"",
+		},
+		{
+			"Rollup is not the only element",
+			base.GroupBy(ur.USER_ID, Rollup(ur.ROLE, ur.COHORT)),
+			"SELECT ur.role, ur.cohort, GROUPING(ur.role, ur.cohort), COUNT(*) FROM devlab.user_roles AS ur" +
+				" GROUP BY ur.user_id, ?",
+			"unsupported grouping: MySQL only supports WITH ROLLUP when Rollup is the only GROUP BY element",
+		},
+		{
+			"empty Rollup",
+			base.GroupBy(Rollup()),
+			"SELECT ur.role, ur.cohort, GROUPING(ur.role, ur.cohort), COUNT(*) FROM devlab.user_roles AS ur" +
+				" GROUP BY ?",
+			"Rollup needs at least one field",
+		},
+		{
+			"empty Grouping",
+			From(ur).Select(Grouping()).GroupBy(Rollup(ur.ROLE)),
+			"SELECT GROUPING(?) FROM devlab.user_roles AS ur GROUP BY ur.role WITH ROLLUP",
+			"GROUPING needs at least one field",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			if tt.wantErr == "" {
+				is.Equal(0, len(gotArgs))
+				return
+			}
+			err := ArgsError(gotArgs)
+			is.True(err != nil)
+			is.Equal(tt.wantErr, err.Error())
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package sq
+
+import "strings"
+
+// reservedWords are the MySQL reserved words. They cannot be used as an
+// identifier unless they are quoted.
+var reservedWords = map[string]bool{
+	"accessible": true, "add": true, "all": true, "alter": true, "analyze": true,
+	"and": true, "as": true, "asc": true, "asensitive": true, "before": true,
+	"between": true, "bigint": true, "binary": true, "blob": true, "both": true,
+	"by": true, "call": true, "cascade": true, "case": true, "change": true,
+	"char": true, "character": true, "check": true, "collate": true,
+	"column": true, "condition": true, "constraint": true, "continue": true,
+	"convert": true, "create": true, "cross": true, "cube": true,
+	"cume_dist": true, "current_date": true, "current_time": true,
+	"current_timestamp": true, "current_user": true, "cursor": true,
+	"database": true, "databases": true, "day_hour": true,
+	"day_microsecond": true, "day_minute": true, "day_second": true,
+	"dec": true, "decimal": true, "declare": true, "default": true,
+	"delayed": true, "delete": true, "dense_rank": true, "desc": true,
+	"describe": true, "deterministic": true, "distinct": true,
+	"distinctrow": true, "div": true, "double": true, "drop": true,
+	"dual": true, "each": true, "else": true, "elseif": true, "empty": true,
+	"enclosed": true, "escaped": true, "except": true, "exists": true,
+	"exit": true, "explain": true, "false": true, "fetch": true,
+	"first_value": true, "float": true, "float4": true, "float8": true,
+	"for": true, "force": true, "foreign": true, "from": true,
+	"fulltext": true, "function": true, "generated": true, "get": true,
+	"grant": true, "group": true, "grouping": true, "groups": true,
+	"having": true, "high_priority": true, "hour_microsecond": true,
+	"hour_minute": true, "hour_second": true, "if": true, "ignore": true,
+	"in": true, "index": true, "infile": true, "inner": true, "inout": true,
+	"insensitive": true, "insert": true, "int": true, "int1": true,
+	"int2": true, "int3": true, "int4": true, "int8": true, "integer": true,
+	"intersect": true, "interval": true, "into": true,
+	"io_after_gtids": true, "io_before_gtids": true, "is": true,
+	"iterate": true, "join": true, "json_table": true, "key": true,
+	"keys": true, "kill": true, "lag": true, "last_value": true,
+	"lateral": true, "lead": true, "leading": true, "leave": true,
+	"left": true, "like": true, "limit": true, "linear": true, "lines": true,
+	"load": true, "localtime": true, "localtimestamp": true, "lock": true,
+	"long": true, "longblob": true, "longtext": true, "loop": true,
+	"low_priority": true, "master_bind": true,
+	"master_ssl_verify_server_cert": true, "match": true, "maxvalue": true,
+	"mediumblob": true, "mediumint": true, "mediumtext": true,
+	"middleint": true, "minute_microsecond": true, "minute_second": true,
+	"mod": true, "modifies": true, "natural": true, "not": true,
+	"no_write_to_binlog": true, "nth_value": true, "ntile": true,
+	"null": true, "numeric": true, "of": true, "on": true, "optimize": true,
+	"optimizer_costs": true, "option": true, "optionally": true, "or": true,
+	"order": true, "out": true, "outer": true, "outfile": true, "over": true,
+	"partition": true, "percent_rank": true, "precision": true,
+	"primary": true, "procedure": true, "purge": true, "range": true,
+	"rank": true, "read": true, "reads": true, "read_write": true,
+	"real": true, "recursive": true, "references": true, "regexp": true,
+	"release": true, "rename": true, "repeat": true, "replace": true,
+	"require": true, "resignal": true, "restrict": true, "return": true,
+	"revoke": true, "right": true, "rlike": true, "row": true, "rows": true,
+	"row_number": true, "schema": true, "schemas": true,
+	"second_microsecond": true, "select": true, "sensitive": true,
+	"separator": true, "set": true, "show": true, "signal": true,
+	"smallint": true, "spatial": true, "specific": true, "sql": true,
+	"sqlexception": true, "sqlstate": true, "sqlwarning": true,
+	"sql_big_result": true, "sql_calc_found_rows": true,
+	"sql_small_result": true, "ssl": true, "starting": true, "stored": true,
+	"straight_join": true, "system": true, "table": true, "terminated": true,
+	"then": true, "tinyblob": true, "tinyint": true, "tinytext": true,
+	"to": true, "trailing": true, "trigger": true, "true": true, "undo": true,
+	"union": true, "unique": true, "unlock": true, "unsigned": true,
+	"update": true, "usage": true, "use": true, "using": true,
+	"utc_date": true, "utc_time": true, "utc_timestamp": true, "values": true,
+	"varbinary": true, "varchar": true, "varcharacter": true, "varying": true,
+	"virtual": true, "when": true, "where": true, "while": true,
+	"window": true, "with": true, "write": true, "xor": true,
+	"year_month": true, "zerofill": true,
+}
+
+// QuoteIdentifier returns the name as a MySQL identifier, quoted with
+// backticks if it has to be quoted to be read back as exactly the same name
+// i.e. if it is a reserved word, or if it has any character that is not
+// allowed in an unquoted identifier. Embedded backticks are escaped by
+// doubling them.
+func QuoteIdentifier(name string) string {
+	buf := &strings.Builder{}
+	appendIdentifier(buf, name, true)
+	return buf.String()
+}
+
+// appendIdentifier writes the identifier into the buffer, quoting it with
+// backticks if needed.
+//
+// Reserved words and names with whitespace or backticks are always quoted.
+// Names that are case sensitive (i.e. the exact name in the database, like the
+// ones generated by sqgen) are also quoted if they have any other character
+// that is not allowed in an unquoted identifier, or if they start with a
+// digit. MySQL does not fold the case of identifiers so upper case letters
+// never have to be quoted.
+func appendIdentifier(buf *strings.Builder, name string, caseSensitive bool) {
+	if !identifierNeedsQuotes(name, caseSensitive) {
+		buf.WriteString(name)
+		return
+	}
+	buf.WriteString("`")
+	buf.WriteString(strings.ReplaceAll(name, "`", "``"))
+	buf.WriteString("`")
+}
+
+// identifierNeedsQuotes reports whether the identifier has to be quoted. See
+// appendIdentifier.
+func identifierNeedsQuotes(name string, caseSensitive bool) bool {
+	if name == "" {
+		return false
+	}
+	if !caseSensitive && len(name) > 1 && name[0] == '`' && name[len(name)-1] == '`' {
+		// already quoted by the user
+		return false
+	}
+	if reservedWords[strings.ToLower(name)] {
+		return true
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '`' || c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			return true
+		case !caseSensitive:
+			continue
+		case c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80:
+			continue
+		case i > 0 && c >= '0' && c <= '9':
+			continue
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// qualify returns the column qualified with the table qualifier i.e.
+// 'qualifier.column'. The qualifier is quoted if needed (see appendIdentifier),
+// the column is written as is.
+func qualify(qualifier, column string) string {
+	buf := &strings.Builder{}
+	appendIdentifier(buf, qualifier, false)
+	buf.WriteString(".")
+	buf.WriteString(column)
+	return buf.String()
+}
+
+// appendIdentifiers writes the comma separated list of identifiers into the
+// buffer.
+func appendIdentifiers(buf *strings.Builder, names []string) {
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		appendIdentifier(buf, name, false)
+	}
+}
+
+// appendColumn writes the column into the buffer, qualified with the alias
+// or name of its table unless the table qualifier is excluded. The table and
+// column names are case sensitive if the table is.
+func appendColumn(buf *strings.Builder, table Table, name string, excludedTableQualifiers []string) {
+	caseSensitive := isCaseSensitive(table)
+	tableQualifier := table.GetAlias()
+	qualifierCaseSensitive := false
+	if tableQualifier == "" {
+		tableQualifier = table.GetName()
+		qualifierCaseSensitive = caseSensitive
+	}
+	for _, excludedTableQualifier := range excludedTableQualifiers {
+		if tableQualifier == excludedTableQualifier {
+			tableQualifier = ""
+			break
+		}
+	}
+	if tableQualifier != "" {
+		appendIdentifier(buf, tableQualifier, qualifierCaseSensitive)
+		buf.WriteString(".")
+	}
+	appendIdentifier(buf, name, caseSensitive)
+}
+
+// isCaseSensitive reports whether the names of the table and its columns are
+// case sensitive.
+func isCaseSensitive(table Table) bool {
+	t, ok := table.(interface{ isCaseSensitive() bool })
+	return ok && t.isCaseSensitive()
+}
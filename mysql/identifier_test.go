@@ -0,0 +1,80 @@
+package sq
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	type TT struct {
+		description string
+		name        string
+		want        string
+	}
+	tests := []TT{
+		{"empty", "", ""},
+		{"plain", "user_id", "user_id"},
+		{"reserved word", "order", "`order`"},
+		{"reserved word upper case", "KEY", "`KEY`"},
+		{"non-reserved key word", "user", "user"},
+		{"mixed case", "createdAt", "createdAt"},
+		{"starts with a digit", "1st", "`1st`"},
+		{"special characters", "user-roles", "`user-roles`"},
+		{"embedded backtick", "say `hi`", "`say ``hi```"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			is.Equal(tt.want, QuoteIdentifier(tt.name))
+		})
+	}
+}
+
+func TestIdentifiers(t *testing.T) {
+	type TT struct {
+		description string
+		q           Query
+		wantQuery   string
+	}
+	tbl := &TableInfo{Schema: "devlab", Name: "order", CaseSensitive: true}
+	key := NewStringField("key", tbl)
+	tests := []TT{
+		{
+			"columns",
+			Select(key, NewStringField("first name", tbl)).From(tbl),
+			"SELECT `order`.`key`, `order`.`first name` FROM devlab.`order`",
+		},
+		{
+			"aliases",
+			Select(key.As("desc")).From(&TableInfo{Schema: "devlab", Name: "order", Alias: "group", CaseSensitive: true}),
+			"SELECT `order`.`key` AS `desc` FROM devlab.`order` AS `group`",
+		},
+		{
+			"CTE",
+			Select(Int(1)).With(NewCTE("select", Select(Int(1)), "from", "to")).From(NewCTE("select", nil)),
+			"WITH `select` (`from`, `to`) AS (SELECT ?) SELECT ? FROM `select`",
+		},
+		{
+			"derived table qualifiers",
+			Select(NewCTE("select", nil).Get("id"), NewCTE("x", nil).As("group").Get("id"), Select(key).From(tbl).As("order").Get("`key`")),
+			"SELECT `select`.id, `group`.id, `order`.`key`",
+		},
+		{
+			"window",
+			Select(Fieldf("SUM(?) OVER ?", key, PartitionBy(key).As("window").Name())).From(tbl).Window(PartitionBy(key).As("window")),
+			"SELECT SUM(`order`.`key`) OVER `window` FROM devlab.`order` WINDOW `window` AS (PARTITION BY `order`.`key`)",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, _ := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+		})
+	}
+}
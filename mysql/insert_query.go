@@ -17,6 +17,7 @@ type InsertQuery struct {
 	Alias  string
 	// INSERT INTO
 	Ignore        bool
+	Replace       bool
 	IntoTable     BaseTable
 	InsertColumns Fields
 	// VALUES
@@ -25,6 +26,9 @@ type InsertQuery struct {
 	SelectQuery *SelectQuery
 	// ON DUPLICATE KEY
 	Resolution Assignments
+	// DialectVersion is the version of the MySQL server that the query is
+	// written for. It picks between syntax that differs across versions.
+	DialectVersion DialectVersion
 	// DB
 	DB DB
 	// Logging
@@ -39,16 +43,36 @@ func (q InsertQuery) ToSQL() (string, []interface{}) {
 	buf := &strings.Builder{}
 	var args []interface{}
 	q.AppendSQL(buf, &args)
+	unescapeQuestionMarks(buf)
 	return buf.String(), args
 }
 
+// ToSQLInline marshals the InsertQuery into a query string with the values inlined
+// as SQL literals instead of bind parameters. It is meant for where bind
+// parameters are not allowed e.g. in DDL, otherwise use ToSQL. It returns
+// an error if a value cannot be written as a literal.
+func (q InsertQuery) ToSQLInline() (string, error) {
+	q.Log = nil
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args)
+	query, err := questionInterpolate(buf.String(), args)
+	if err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
 // AppendSQL marshals the InsertQuery into a buffer and args slice.
 func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	var excludedTableQualifiers []string
 	// INSERT INTO
-	if q.Ignore {
+	switch {
+	case q.Replace:
+		buf.WriteString("REPLACE INTO ")
+	case q.Ignore:
 		buf.WriteString("INSERT IGNORE INTO ")
-	} else {
+	default:
 		buf.WriteString("INSERT INTO ")
 	}
 	if q.IntoTable == nil {
@@ -69,10 +93,15 @@ func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 		buf.WriteString(")")
 	}
 	// VALUES/SELECT
+	resolution := q.Resolution
 	switch {
 	case len(q.RowValues) > 0:
 		buf.WriteString(" VALUES ")
 		q.RowValues.AppendSQL(buf, args)
+		if len(q.Resolution) > 0 && !q.Replace && q.DialectVersion >= MySQL8019 {
+			buf.WriteString(" AS " + rowAlias)
+			resolution = withRowAlias(q.Resolution, rowAlias).(Assignments)
+		}
 	case q.SelectQuery != nil:
 		buf.WriteString(" ")
 		q.SelectQuery.Nested = true
@@ -80,8 +109,15 @@ func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	}
 	// ON DUPLICATE KEY UPDATE
 	if len(q.Resolution) > 0 {
-		buf.WriteString(" ON DUPLICATE KEY UPDATE ")
-		q.Resolution.AppendSQLExclude(buf, args, excludedTableQualifiers)
+		if q.Replace {
+			// REPLACE already deletes the duplicate rows, it has no ON
+			// DUPLICATE KEY UPDATE clause
+			buf.WriteString(" ")
+			appendBuildError(buf, args, errors.New("REPLACE cannot be used with ON DUPLICATE KEY UPDATE"))
+		} else {
+			buf.WriteString(" ON DUPLICATE KEY UPDATE ")
+			resolution.AppendSQLExclude(buf, args, excludedTableQualifiers)
+		}
 	}
 	if !q.Nested {
 		if q.Log != nil {
@@ -110,7 +146,6 @@ func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 func InsertInto(table BaseTable) InsertQuery {
 	return InsertQuery{
 		IntoTable: table,
-		Alias:     RandomString(8),
 	}
 }
 
@@ -119,7 +154,15 @@ func InsertIgnoreInto(table BaseTable) InsertQuery {
 	return InsertQuery{
 		Ignore:    true,
 		IntoTable: table,
-		Alias:     RandomString(8),
+	}
+}
+
+// ReplaceInto creates a new InsertQuery that replaces rows with the same
+// primary key or unique key values i.e. 'REPLACE INTO table'.
+func ReplaceInto(table BaseTable) InsertQuery {
+	return InsertQuery{
+		Replace:   true,
+		IntoTable: table,
 	}
 }
 
@@ -136,6 +179,13 @@ func (q InsertQuery) InsertIgnoreInto(table BaseTable) InsertQuery {
 	return q
 }
 
+// ReplaceInto sets the replace table for the InsertQuery.
+func (q InsertQuery) ReplaceInto(table BaseTable) InsertQuery {
+	q.Replace = true
+	q.IntoTable = table
+	return q
+}
+
 // Columns sets the insert columns for the InsertQuery.
 func (q InsertQuery) Columns(fields ...Field) InsertQuery {
 	q.InsertColumns = fields
@@ -176,14 +226,136 @@ func (q InsertQuery) OnDuplicateKeyUpdate(assignments ...Assignment) InsertQuery
 	return q
 }
 
+// WithVersion sets the DialectVersion of the InsertQuery.
+func (q InsertQuery) WithVersion(version DialectVersion) InsertQuery {
+	q.DialectVersion = version
+	return q
+}
+
 // Values wraps a field to simulate the VALUES(field) MySQL construct for the
-// ON DUPLICATE KEY UPDATE clause.
+// ON DUPLICATE KEY UPDATE clause. VALUES(field) is deprecated from MySQL
+// 8.0.20 onwards, use New instead.
 func Values(field Field) CustomField {
 	return CustomField{
 		Format: "VALUES(" + field.GetName() + ")",
 	}
 }
 
+// rowAlias is the alias of the row being inserted i.e.
+// 'INSERT INTO table VALUES (...) AS new'.
+const rowAlias = "new"
+
+// NewField represents the value that an INSERT tried to insert into a column,
+// for use in the ON DUPLICATE KEY UPDATE clause.
+type NewField struct {
+	Field Field
+	// RowAlias is the alias of the row being inserted. The NewField is written
+	// as 'RowAlias.field' if it is set and as 'VALUES(field)' otherwise. It is
+	// set by the InsertQuery that the NewField is used in.
+	RowAlias string
+}
+
+// New returns a NewField for the field. It is written as 'new.field' if the
+// InsertQuery uses the MySQL 8.0.19 row alias syntax (see WithVersion) and as
+// 'VALUES(field)' otherwise, so the same ON DUPLICATE KEY UPDATE clause works
+// on every MySQL version.
+func New(field Field) NewField {
+	return NewField{Field: field}
+}
+
+// AppendSQLExclude marshals the NewField into a buffer and an args slice.
+func (f NewField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
+	name := ""
+	if f.Field != nil {
+		name = f.Field.GetName()
+	}
+	if f.RowAlias != "" {
+		appendIdentifier(buf, f.RowAlias, false)
+		buf.WriteString(".")
+		appendIdentifier(buf, name, false)
+		return
+	}
+	buf.WriteString("VALUES(")
+	appendIdentifier(buf, name, false)
+	buf.WriteString(")")
+}
+
+// withRowAlias returns a copy of the value with the RowAlias of every NewField
+// in it set to alias. Only the Assignments, Predicates and Fields of this
+// package are looked into, a NewField inside any other type keeps being
+// written as 'VALUES(field)' (which MySQL still accepts).
+func withRowAlias(value interface{}, alias string) interface{} {
+	switch v := value.(type) {
+	case NewField:
+		v.RowAlias = alias
+		return v
+	case Assignments:
+		assignments := make(Assignments, len(v))
+		for i, assignment := range v {
+			assignments[i] = withRowAlias(assignment, alias).(Assignment)
+		}
+		return assignments
+	case FieldAssignment:
+		v.Value = withRowAlias(v.Value, alias)
+		return v
+	case CustomField:
+		v.Values = withRowAliases(v.Values, alias)
+		return v
+	case CustomPredicate:
+		v.Values = withRowAliases(v.Values, alias)
+		return v
+	case VariadicPredicate:
+		predicates := make([]Predicate, len(v.Predicates))
+		for i, predicate := range v.Predicates {
+			predicates[i] = withRowAlias(predicate, alias).(Predicate)
+		}
+		v.Predicates = predicates
+		return v
+	case NumberField:
+		v.values = withRowAliases(v.values, alias)
+		return v
+	case StringField:
+		v.values = withRowAliases(v.values, alias)
+		return v
+	case TimeField:
+		v.values = withRowAliases(v.values, alias)
+		return v
+	case BooleanField:
+		v.values = withRowAliases(v.values, alias)
+		return v
+	case JSONField:
+		v.values = withRowAliases(v.values, alias)
+		return v
+	}
+	return value
+}
+
+// withRowAliases calls withRowAlias on each of the values.
+func withRowAliases(values []interface{}, alias string) []interface{} {
+	if len(values) == 0 {
+		return values
+	}
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = withRowAlias(value, alias)
+	}
+	return result
+}
+
+// GetAlias implements the Field interface. It always returns an empty string.
+func (f NewField) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Field interface. It returns the name of the
+// underlying field.
+func (f NewField) GetName() string {
+	if f.Field == nil {
+		return ""
+	}
+	return f.Field.GetName()
+}
+
 // Exec will execute the InsertQuery with the given DB. It will only compute
 // the lastInsertID if the ElastInsertID ExecFlag is passed to it. It will only
 // compute the rowsAffected if the ErowsAffected Execflag is passed to it. To
@@ -234,29 +406,98 @@ func (q InsertQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (las
 	var tmpargs []interface{}
 	q.LogSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs)
+	unescapeQuestionMarks(tmpbuf)
+	if err = ArgsError(tmpargs); err != nil {
+		return lastInsertID, rowsAffected, newQueryError("INSERT", tmpbuf.String(), tmpargs, nil, err)
+	}
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
 		res, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return lastInsertID, rowsAffected, err
+		return lastInsertID, rowsAffected, newQueryError("INSERT", tmpbuf.String(), tmpargs, nil, err)
 	}
 	if res != nil && ElastInsertID&flag != 0 {
 		lastInsertID, err = res.LastInsertId()
 		if err != nil {
-			return lastInsertID, rowsAffected, err
+			return lastInsertID, rowsAffected, newQueryError("INSERT", tmpbuf.String(), tmpargs, nil, err)
 		}
 	}
 	if res != nil && ErowsAffected&flag != 0 {
 		rowsAffected, err = res.RowsAffected()
 		if err != nil {
-			return lastInsertID, rowsAffected, err
+			return lastInsertID, rowsAffected, newQueryError("INSERT", tmpbuf.String(), tmpargs, nil, err)
 		}
 	}
 	return lastInsertID, rowsAffected, nil
 }
 
+// UpsertResult is the outcome of an INSERT ... ON DUPLICATE KEY UPDATE query.
+// Inserted and Updated are UnknownCount if they cannot be told apart from
+// RowsAffected, see ExecUpsertContext.
+type UpsertResult struct {
+	LastInsertID int64
+	RowsAffected int64
+	Inserted     int64
+	Updated      int64
+}
+
+// UnknownCount is the count of inserted or updated rows in an UpsertResult
+// when it is not known.
+const UnknownCount = -1
+
+// ExecUpsert will execute the InsertQuery with the given DB and report how
+// many rows were inserted and how many were updated. See ExecUpsertContext.
+func (q InsertQuery) ExecUpsert(db DB) (result UpsertResult, err error) {
+	q.LogSkip += 1
+	return q.ExecUpsertContext(nil, db)
+}
+
+// ExecUpsertContext will execute the InsertQuery with the given DB and
+// context and report how many rows were inserted and how many were updated.
+//
+// MySQL only reports the total rows affected, which counts 1 for every
+// inserted row, 2 for every updated row and 0 for every row that was left
+// unchanged. For a single row that total says exactly what happened to the
+// row, but for more rows different outcomes add up to the same total (e.g. one
+// row updated and one left unchanged, or two rows inserted), so Inserted and
+// Updated are only reported for single row upserts and are UnknownCount
+// otherwise. RowsAffected is always reported. The counts are not available for
+// INSERT ... SELECT. The DB connection must not use the CLIENT_FOUND_ROWS
+// flag.
+func (q InsertQuery) ExecUpsertContext(ctx context.Context, db DB) (result UpsertResult, err error) {
+	if q.SelectQuery != nil {
+		return result, errors.New("cannot count the inserted and updated rows of an INSERT ... SELECT")
+	}
+	q.LogSkip += 1
+	result.LastInsertID, result.RowsAffected, err = q.ExecContext(ctx, db, ElastInsertID|ErowsAffected)
+	if err != nil {
+		return result, err
+	}
+	result.Inserted, result.Updated = upsertCounts(int64(len(q.RowValues)), result.RowsAffected)
+	return result, nil
+}
+
+// upsertCounts derives the number of inserted and updated rows from the number
+// of rows in an upsert and the rows affected. It returns UnknownCount for both
+// unless there is a single row.
+func upsertCounts(rows, rowsAffected int64) (inserted, updated int64) {
+	if rows != 1 {
+		return UnknownCount, UnknownCount
+	}
+	switch rowsAffected {
+	case 1:
+		return 1, 0
+	case 2:
+		return 0, 1
+	case 0:
+		// the row was left unchanged
+		return 0, 0
+	}
+	return UnknownCount, UnknownCount
+}
+
 // As aliases the InsertQuery i.e. 'query AS alias'.
 func (q InsertQuery) As(alias string) InsertQuery {
 	q.Alias = alias
@@ -92,6 +92,82 @@ func TestInsertQuery_ToSQL(t *testing.T) {
 			wantArgs := []interface{}{"aaa", "aaa@email.com", "bbb", "bbb@email.com"}
 			return TT{desc, q, wantQuery, wantArgs}
 		}(),
+		{
+			"Replace",
+			WithDefaultLog(0).
+				ReplaceInto(u).
+				Columns(u.USER_ID, u.DISPLAYNAME).
+				Values(1, "aaa"),
+			"REPLACE INTO devlab.users (user_id, displayname) VALUES (?, ?)",
+			[]interface{}{1, "aaa"},
+		},
+		{
+			"New without row alias",
+			InsertInto(u).
+				Columns(u.DISPLAYNAME, u.EMAIL).
+				Values("aaa", "aaa@email.com").
+				OnDuplicateKeyUpdate(
+					u.DISPLAYNAME.Set(New(u.DISPLAYNAME)),
+					u.EMAIL.Set(Fieldf("CONCAT(?, ?)", u.EMAIL, New(u.EMAIL))),
+				),
+			"INSERT INTO devlab.users (displayname, email)" +
+				" VALUES (?, ?)" +
+				" ON DUPLICATE KEY UPDATE" +
+				" displayname = VALUES(displayname), email = CONCAT(email, VALUES(email))",
+			[]interface{}{"aaa", "aaa@email.com"},
+		},
+		{
+			"New with row alias",
+			InsertInto(u).
+				WithVersion(MySQL8019).
+				Columns(u.DISPLAYNAME, u.EMAIL).
+				Values("aaa", "aaa@email.com").
+				OnDuplicateKeyUpdate(
+					u.DISPLAYNAME.Set(New(u.DISPLAYNAME)),
+					u.EMAIL.Set(Fieldf("CONCAT(?, ?)", u.EMAIL, New(u.EMAIL))),
+				),
+			"INSERT INTO devlab.users (displayname, email)" +
+				" VALUES (?, ?) AS new" +
+				" ON DUPLICATE KEY UPDATE" +
+				" displayname = new.displayname, email = CONCAT(email, new.email)",
+			[]interface{}{"aaa", "aaa@email.com"},
+		},
+		{
+			"New with row alias and Select",
+			InsertInto(u).
+				WithVersion(MySQL8019).
+				Columns(u.DISPLAYNAME).
+				Select(Select(u.DISPLAYNAME).From(u)).
+				OnDuplicateKeyUpdate(u.DISPLAYNAME.Set(New(u.DISPLAYNAME))),
+			"INSERT INTO devlab.users (displayname)" +
+				" SELECT u.displayname FROM devlab.users AS u" +
+				" ON DUPLICATE KEY UPDATE" +
+				" displayname = VALUES(displayname)",
+			nil,
+		},
+		{
+			"Replace with OnDuplicateKeyUpdate",
+			ReplaceInto(u).
+				WithVersion(MySQL8019).
+				Columns(u.USER_ID, u.DISPLAYNAME).
+				Values(1, "aaa").
+				OnDuplicateKeyUpdate(u.DISPLAYNAME.Set(New(u.DISPLAYNAME))),
+			"REPLACE INTO devlab.users (user_id, displayname) VALUES (?, ?) ?",
+			[]interface{}{1, "aaa", &BuildError{Err: errors.New("REPLACE cannot be used with ON DUPLICATE KEY UPDATE")}},
+		},
+		{
+			"New with row alias in a predicate",
+			InsertInto(u).
+				WithVersion(MySQL8019).
+				Columns(u.DISPLAYNAME).
+				Values("aaa").
+				OnDuplicateKeyUpdate(u.DISPLAYNAME.Set(Fieldf("IF(?, ?, ?)", Predicatef("? IS NULL", New(u.DISPLAYNAME)), u.DISPLAYNAME, New(u.DISPLAYNAME)))),
+			"INSERT INTO devlab.users (displayname)" +
+				" VALUES (?) AS new" +
+				" ON DUPLICATE KEY UPDATE" +
+				" displayname = IF(new.displayname IS NULL, displayname, new.displayname)",
+			[]interface{}{"aaa"},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -106,6 +182,45 @@ func TestInsertQuery_ToSQL(t *testing.T) {
 	}
 }
 
+func TestInsertQuery_upsertCounts(t *testing.T) {
+	type TT struct {
+		description  string
+		rows         int64
+		rowsAffected int64
+		wantInserted int64
+		wantUpdated  int64
+	}
+	tests := []TT{
+		{"inserted", 1, 1, 1, 0},
+		{"updated", 1, 2, 0, 1},
+		{"unchanged", 1, 0, 0, 0},
+		{"unexpected rows affected", 1, 3, UnknownCount, UnknownCount},
+		{"multiple rows", 3, 3, UnknownCount, UnknownCount},
+		{"multiple rows updated or unchanged", 2, 2, UnknownCount, UnknownCount},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotInserted, gotUpdated := upsertCounts(tt.rows, tt.rowsAffected)
+			is.Equal(tt.wantInserted, gotInserted)
+			is.Equal(tt.wantUpdated, gotUpdated)
+		})
+	}
+}
+
+func TestInsertQuery_ExecUpsert(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	_, err := InsertInto(u).
+		Columns(u.DISPLAYNAME).
+		Select(Select(u.DISPLAYNAME).From(u)).
+		OnDuplicateKeyUpdate(u.DISPLAYNAME.Set(New(u.DISPLAYNAME))).
+		ExecUpsert(nil)
+	is.True(err != nil)
+}
+
 func TestInsertQuery_Exec(t *testing.T) {
 	if testing.Short() {
 		return
@@ -0,0 +1,114 @@
+package sq
+
+import "strings"
+
+// This file contains the lexer that finds the question mark ? placeholders in
+// a format string or query. A ? is only a placeholder if it appears outside of
+// string literals, quoted identifiers and comments. A literal ? outside of
+// them is escaped as ??. The ?? escape is kept as is when the format string is
+// expanded, and is only unescaped to a single ? by QuestionInterpolate or when
+// the query is sent to the database (see unescapeQuestionMarks).
+
+// nextPlaceholder returns the index of the next ? placeholder in the query. It
+// reports whether the ? is the start of an escaped ?? instead of a
+// placeholder. If there are no more placeholders, it returns -1.
+func nextPlaceholder(query string) (index int, escaped bool) {
+	for i := 0; i < len(query); i++ {
+		switch query[i] {
+		case '?':
+			return i, i+1 < len(query) && query[i+1] == '?'
+		case '\'', '"':
+			i = skipQuoted(query, i, true)
+		case '`':
+			i = skipQuoted(query, i, false)
+		case '#':
+			i = skipLineComment(query, i)
+		case '-':
+			// MySQL requires the -- to be followed by whitespace or a
+			// control character
+			if strings.HasPrefix(query[i:], "--") && (i+2 == len(query) || query[i+2] <= ' ') {
+				i = skipLineComment(query, i)
+			}
+		case '/':
+			if strings.HasPrefix(query[i:], "/*") {
+				if end := strings.Index(query[i+2:], "*/"); end >= 0 {
+					i += 2 + end + 1
+				} else {
+					return -1, false
+				}
+			}
+		}
+	}
+	return -1, false
+}
+
+// countPlaceholders returns the number of ? placeholders in the query.
+func countPlaceholders(query string) int {
+	var count int
+	for {
+		i, escaped := nextPlaceholder(query)
+		if i < 0 {
+			return count
+		}
+		if escaped {
+			query = query[i+2:]
+			continue
+		}
+		count++
+		query = query[i+1:]
+	}
+}
+
+// skipQuoted returns the index of the quote that closes the quoted string or
+// identifier starting at query[start]. A doubled quote is an escaped quote,
+// and so is a quote preceded by a backslash if backslash is true. If the quote
+// is never closed, it returns the index of the last byte of the query.
+func skipQuoted(query string, start int, backslash bool) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return len(query) - 1
+}
+
+// skipLineComment returns the index of the newline that ends the # or --
+// comment starting at query[start].
+func skipLineComment(query string, start int) int {
+	if end := strings.IndexByte(query[start:], '\n'); end >= 0 {
+		return start + end
+	}
+	return len(query) - 1
+}
+
+// unescapeQuestionMarks unescapes every ?? in the query in the buffer to a
+// single ?. It is called on the final query string before it is returned by
+// ToSQL or run by Fetch, Exec or Cursor, because the database driver knows
+// nothing about the escape.
+func unescapeQuestionMarks(buf *strings.Builder) {
+	query := buf.String()
+	if !strings.Contains(query, "??") {
+		return
+	}
+	buf.Reset()
+	for i, escaped := nextPlaceholder(query); i >= 0; i, escaped = nextPlaceholder(query) {
+		// the ? is written and the second ? of an escaped ?? is skipped
+		buf.WriteString(query[:i+1])
+		if escaped {
+			query = query[i+2:]
+		} else {
+			query = query[i+1:]
+		}
+	}
+	buf.WriteString(query)
+}
@@ -0,0 +1,153 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestExpandValues(t *testing.T) {
+	type TT struct {
+		description string
+		format      string
+		values      []interface{}
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		{
+			"placeholders",
+			"? = ?",
+			[]interface{}{1, "a"},
+			"? = ?",
+			[]interface{}{1, "a"},
+		},
+		{
+			"string literals",
+			`x = 'what?' AND y = "it\"s ?" AND z = 'it''s ?' AND w = ?`,
+			[]interface{}{1},
+			`x = 'what?' AND y = "it\"s ?" AND z = 'it''s ?' AND w = ?`,
+			[]interface{}{1},
+		},
+		{
+			"quoted identifier",
+			"`what?` = ?",
+			[]interface{}{1},
+			"`what?` = ?",
+			[]interface{}{1},
+		},
+		{
+			"comments",
+			"? # what?\n-- what?\n/* what? */ + ?",
+			[]interface{}{1, 2},
+			"? # what?\n-- what?\n/* what? */ + ?",
+			[]interface{}{1, 2},
+		},
+		{
+			"-- without a space is not a comment",
+			"?--?",
+			[]interface{}{1, 2},
+			"?--?",
+			[]interface{}{1, 2},
+		},
+		{
+			"escaped question mark",
+			"x ?? ? AND y = '??'",
+			[]interface{}{1},
+			"x ?? ? AND y = '??'",
+			[]interface{}{1},
+		},
+		{
+			"too few values",
+			"? = ?",
+			[]interface{}{1},
+			"?",
+			[]interface{}{&BuildError{Err: errors.New(`format "? = ?" has 2 placeholders, but 1 values were given`)}},
+		},
+		{
+			"too many values",
+			"x = '?'",
+			[]interface{}{1},
+			"?",
+			[]interface{}{&BuildError{Err: errors.New(`format "x = '?'" has 0 placeholders, but 1 values were given`)}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			ExpandValues(buf, &args, nil, tt.format, tt.values)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestQuestionInterpolate(t *testing.T) {
+	is := is.New(t)
+	is.Equal(
+		"SELECT 'a' WHERE x = 'what?' AND y = 1",
+		QuestionInterpolate("SELECT ? WHERE x = 'what?' AND y = ?", "a", 1),
+	)
+	is.Equal(
+		"SELECT 'a' WHERE x ? 'b' AND y = '??'",
+		QuestionInterpolate("SELECT ? WHERE x ?? ? AND y = '??'", "a", "b"),
+	)
+}
+
+// queryRecorder is a DB that records the queries sent to it instead of running
+// them.
+type queryRecorder struct{ queries []string }
+
+var errRecorded = errors.New("recorded")
+
+func (db *queryRecorder) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	db.queries = append(db.queries, query)
+	return nil, errRecorded
+}
+
+func (db *queryRecorder) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return db.Query(query, args...)
+}
+
+func (db *queryRecorder) Exec(query string, args ...interface{}) (sql.Result, error) {
+	db.queries = append(db.queries, query)
+	return nil, errRecorded
+}
+
+func (db *queryRecorder) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.Exec(query, args...)
+}
+
+func TestUnescapeQuestionMarks(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	q := From(u).Where(Predicatef("u.displayname ?? ? AND u.email = '??'", "a"))
+	wantQuery := "SELECT u.user_id FROM devlab.users AS u WHERE u.displayname ? ? AND u.email = '??'"
+
+	// ToSQL returns the query as it is sent to the database
+	gotQuery, gotArgs := q.Select(u.USER_ID).ToSQL()
+	is.Equal(wantQuery, gotQuery)
+	is.Equal([]interface{}{"a"}, gotArgs)
+
+	// Fetch, Cursor and Exec send the query without the ?? escape
+	db := &queryRecorder{}
+	err := q.Selectx(func(row *Row) { row.Int(u.USER_ID) }, nil).Fetch(db)
+	is.True(errors.Is(err, errRecorded))
+	_, err = q.Cursor(nil, db, func(row *Row) { row.Int(u.USER_ID) })
+	is.True(errors.Is(err, errRecorded))
+	_, err = DeleteFrom(u).Where(Predicatef("u.displayname ?? ?", "a")).Exec(db, 0)
+	is.True(errors.Is(err, errRecorded))
+	is.Equal([]string{
+		wantQuery,
+		wantQuery,
+		"DELETE FROM u WHERE u.displayname ? ?",
+	}, db.queries)
+}
@@ -0,0 +1,171 @@
+package sq
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// literal returns the SQL literal of the value (see appendLiteral).
+func literal(value interface{}) (string, error) {
+	buf := &strings.Builder{}
+	err := appendLiteral(buf, value)
+	return buf.String(), err
+}
+
+// appendLiteral writes the value into the buffer as a MySQL literal, so that
+// the SQL can be run without bind parameters:
+//
+// - strings are single quoted with embedded quotes doubled and backslashes
+// (and control characters) backslash escaped, as required by the default
+// sql_mode where NO_BACKSLASH_ESCAPES is off.
+//
+// - []byte is written as a hexadecimal literal i.e. X'deadbeef'.
+//
+// - time.Time is written with its time zone offset i.e.
+// '2006-01-02 15:04:05.999999-07:00', which requires MySQL 8.0.19 or later.
+//
+// - driver.Valuers are written as the literal of their driver.Value. If the
+// driver.Valuer fails, its error is returned.
+//
+// - json.RawMessage is written as a string.
+//
+// Any other value is written according to its kind, or if it has no SQL
+// equivalent (e.g. a slice, struct or map) it is marshalled into JSON and
+// written as a string. If it cannot be marshalled into JSON, the error is
+// returned.
+func appendLiteral(buf *strings.Builder, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("NULL")
+	case bool:
+		if v {
+			buf.WriteString("TRUE")
+		} else {
+			buf.WriteString("FALSE")
+		}
+	case string:
+		appendStringLiteral(buf, v)
+	case []byte:
+		if v == nil {
+			buf.WriteString("NULL")
+			return nil
+		}
+		buf.WriteString("X'")
+		buf.WriteString(hex.EncodeToString(v))
+		buf.WriteString("'")
+	case int:
+		appendNumberLiteral(buf, strconv.FormatInt(int64(v), 10))
+	case int64:
+		appendNumberLiteral(buf, strconv.FormatInt(v, 10))
+	case float64:
+		appendNumberLiteral(buf, strconv.FormatFloat(v, 'g', -1, 64))
+	case time.Time:
+		buf.WriteString("'")
+		buf.WriteString(v.Format("2006-01-02 15:04:05.999999-07:00"))
+		buf.WriteString("'")
+	case driver.Valuer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			buf.WriteString("NULL")
+			return nil
+		}
+		value, err := v.Value()
+		if err != nil {
+			return err
+		}
+		return appendLiteral(buf, value)
+	case json.RawMessage:
+		appendStringLiteral(buf, string(v))
+	default:
+		return appendReflectLiteral(buf, reflect.ValueOf(value))
+	}
+	return nil
+}
+
+// appendReflectLiteral writes the literal of a value that is not one of the
+// types handled by appendLiteral, based on its kind.
+func appendReflectLiteral(buf *strings.Builder, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			buf.WriteString("NULL")
+			return nil
+		}
+		return appendLiteral(buf, rv.Elem().Interface())
+	case reflect.Bool:
+		return appendLiteral(buf, rv.Bool())
+	case reflect.String:
+		appendStringLiteral(buf, rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		appendNumberLiteral(buf, strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		buf.WriteString(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32:
+		appendNumberLiteral(buf, strconv.FormatFloat(rv.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		appendNumberLiteral(buf, strconv.FormatFloat(rv.Float(), 'g', -1, 64))
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			buf.WriteString("NULL")
+			return nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return appendLiteral(buf, b)
+		}
+		return appendJSONLiteral(buf, rv.Interface())
+	default:
+		return appendJSONLiteral(buf, rv.Interface())
+	}
+	return nil
+}
+
+// appendJSONLiteral writes the value into the buffer as a JSON string.
+func appendJSONLiteral(buf *strings.Builder, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	appendStringLiteral(buf, string(b))
+	return nil
+}
+
+// appendStringLiteral writes the string into the buffer as a string literal.
+func appendStringLiteral(buf *strings.Builder, s string) {
+	buf.WriteString("'")
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'':
+			buf.WriteString("''")
+		case '\\':
+			buf.WriteString(`\\`)
+		case 0:
+			buf.WriteString(`\0`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case 0x1a:
+			buf.WriteString(`\Z`)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteString("'")
+}
+
+// appendNumberLiteral writes the formatted number into the buffer. Negative
+// numbers are bracketed, so that a negated literal is written as -(-5) instead
+// of --5, which would start a comment.
+func appendNumberLiteral(buf *strings.Builder, s string) {
+	if strings.HasPrefix(s, "-") {
+		buf.WriteString("(" + s + ")")
+		return
+	}
+	buf.WriteString(s)
+}
@@ -0,0 +1,113 @@
+package sq
+
+import "strings"
+
+// MatchAgainstField represents the 'MATCH (col1, col2...) AGAINST (search
+// modifier)' full-text search expression. It can be used both as a Predicate
+// in the WHERE clause and, through Score(), as a relevance score in the SELECT
+// or ORDER BY clause. The columns must be covered by a FULLTEXT index.
+type MatchAgainstField struct {
+	Alias    string
+	Fields   []StringField
+	Search   string
+	Modifier string
+	Negative bool
+}
+
+// MatchAgainst returns a new MatchAgainstField for the given columns. Call one
+// of InNaturalLanguageMode, InBooleanMode or WithQueryExpansion to supply the
+// search string.
+func MatchAgainst(fields ...StringField) MatchAgainstField {
+	return MatchAgainstField{
+		Fields: fields,
+	}
+}
+
+// AppendSQLExclude marshals the MatchAgainstField into a buffer and an args
+// slice. It propagates the excludedTableQualifiers down to its child elements.
+func (f MatchAgainstField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
+	if f.Negative {
+		buf.WriteString("NOT ")
+	}
+	buf.WriteString("MATCH (")
+	for i, field := range f.Fields {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		field.AppendSQLExclude(buf, args, excludedTableQualifiers)
+	}
+	buf.WriteString(") AGAINST (?")
+	*args = append(*args, f.Search)
+	if f.Modifier != "" {
+		buf.WriteString(" ")
+		buf.WriteString(f.Modifier)
+	}
+	buf.WriteString(")")
+}
+
+// InNaturalLanguageMode returns a new MatchAgainstField that searches for the
+// search string in natural language mode.
+func (f MatchAgainstField) InNaturalLanguageMode(search string) MatchAgainstField {
+	f.Search = search
+	f.Modifier = "IN NATURAL LANGUAGE MODE"
+	return f
+}
+
+// InBooleanMode returns a new MatchAgainstField that searches for the search
+// string in boolean mode, where the search string may contain operators like
+// '+', '-' and '*'.
+func (f MatchAgainstField) InBooleanMode(search string) MatchAgainstField {
+	f.Search = search
+	f.Modifier = "IN BOOLEAN MODE"
+	return f
+}
+
+// WithQueryExpansion returns a new MatchAgainstField that searches for the
+// search string in natural language mode with query expansion.
+func (f MatchAgainstField) WithQueryExpansion(search string) MatchAgainstField {
+	f.Search = search
+	f.Modifier = "WITH QUERY EXPANSION"
+	return f
+}
+
+// Score returns the relevance score of the MatchAgainstField as a NumberField,
+// for use in the SELECT or ORDER BY clause.
+func (f MatchAgainstField) Score() NumberField {
+	f.Negative = false
+	return numberFunction("?", f)
+}
+
+// As returns a new MatchAgainstField with the new field Alias i.e. 'field AS
+// Alias'.
+func (f MatchAgainstField) As(alias string) MatchAgainstField {
+	f.Alias = alias
+	return f
+}
+
+// Not implements the Predicate interface. It inverts the MatchAgainstField
+// i.e. 'NOT MATCH (...) AGAINST (...)'.
+func (f MatchAgainstField) Not() Predicate {
+	f.Negative = !f.Negative
+	return f
+}
+
+// String implements the fmt.Stringer interface. It returns the string
+// representation of a MatchAgainstField.
+func (f MatchAgainstField) String() string {
+	buf := &strings.Builder{}
+	var args []interface{}
+	f.AppendSQLExclude(buf, &args, nil)
+	return QuestionInterpolate(buf.String(), args...)
+}
+
+// GetAlias implements the Field interface. It returns the Alias of the
+// MatchAgainstField.
+func (f MatchAgainstField) GetAlias() string {
+	return f.Alias
+}
+
+// GetName implements the Field interface. It returns the Name of the
+// MatchAgainstField, which is always an empty string.
+func (f MatchAgainstField) GetName() string {
+	return ""
+}
@@ -0,0 +1,77 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestMatchAgainstField(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	articles := &TableInfo{Schema: "devlab", Name: "articles"}
+	title := NewStringField("title", articles)
+	body := NewStringField("body", articles)
+	tests := []TT{
+		{
+			"natural language mode",
+			MatchAgainst(title, body).InNaturalLanguageMode("database"),
+			nil,
+			"MATCH (articles.title, articles.body) AGAINST (? IN NATURAL LANGUAGE MODE)",
+			[]interface{}{"database"},
+		},
+		{
+			"boolean mode",
+			MatchAgainst(title).InBooleanMode("+mysql -oracle"),
+			[]string{"articles"},
+			"MATCH (title) AGAINST (? IN BOOLEAN MODE)",
+			[]interface{}{"+mysql -oracle"},
+		},
+		{
+			"query expansion",
+			MatchAgainst(title, body).WithQueryExpansion("database"),
+			nil,
+			"MATCH (articles.title, articles.body) AGAINST (? WITH QUERY EXPANSION)",
+			[]interface{}{"database"},
+		},
+		{
+			"Not",
+			MatchAgainst(title).InBooleanMode("mysql").Not(),
+			nil,
+			"NOT MATCH (articles.title) AGAINST (? IN BOOLEAN MODE)",
+			[]interface{}{"mysql"},
+		},
+		{
+			"Score",
+			MatchAgainst(title).InNaturalLanguageMode("mysql").Score().Desc(),
+			nil,
+			"MATCH (articles.title) AGAINST (? IN NATURAL LANGUAGE MODE) DESC",
+			[]interface{}{"mysql"},
+		},
+		{
+			"Score arithmetic",
+			MatchAgainst(title).InNaturalLanguageMode("mysql").Score().MulInt(2),
+			nil,
+			"MATCH (articles.title) AGAINST (? IN NATURAL LANGUAGE MODE) * ?",
+			[]interface{}{"mysql", 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
@@ -347,3 +347,51 @@ func TestNumberField_Predicates(t *testing.T) {
 		})
 	}
 }
+
+func TestNumberField_Arithmetic(t *testing.T) {
+	type TT struct {
+		description string
+		f           NumberField
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	price := NewNumberField("price", &TableInfo{Schema: "devlab", Name: "orders"})
+	quantity := NewNumberField("quantity", &TableInfo{Schema: "devlab", Name: "orders"})
+	tests := []TT{
+		{"Add", price.Add(quantity), nil, "orders.price + orders.quantity", nil},
+		{"AddInt", price.AddInt(1), nil, "orders.price + ?", []interface{}{1}},
+		{"SubFloat64", price.SubFloat64(0.5), nil, "orders.price - ?", []interface{}{0.5}},
+		{"Mul", price.Mul(quantity), nil, "orders.price * orders.quantity", nil},
+		{"DivInt", price.DivInt(2), nil, "orders.price / ?", []interface{}{2}},
+		{"ModInt", quantity.ModInt(3), nil, "orders.quantity % ?", []interface{}{3}},
+		{
+			"nested expressions are bracketed",
+			price.Add(quantity).Mul(price.Sub(quantity)),
+			nil,
+			"(orders.price + orders.quantity) * (orders.price - orders.quantity)",
+			nil,
+		},
+		{"Neg", price.Neg().Neg(), nil, "-(-orders.price)", nil},
+		{"Abs", price.Sub(quantity).Abs(), nil, "ABS(orders.price - orders.quantity)", nil},
+		{"Round", price.Round(2), nil, "ROUND(orders.price, ?)", []interface{}{2}},
+		{"Ceil", price.Ceil(), nil, "CEIL(orders.price)", nil},
+		{"Floor", price.Floor(), nil, "FLOOR(orders.price)", nil},
+		{"Power", price.Power(Int(2)), nil, "POWER(orders.price, ?)", []interface{}{2}},
+		{"PowerFloat64", price.PowerFloat64(0.5), nil, "POWER(orders.price, ?)", []interface{}{0.5}},
+		{"Sqrt", price.Sqrt().MulInt(2), nil, "SQRT(orders.price) * ?", []interface{}{2}},
+		{"excludedTableQualifiers", price.Mul(quantity), []string{"orders"}, "price * quantity", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
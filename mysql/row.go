@@ -2,8 +2,11 @@ package sq
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
-	"runtime"
+	"math"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -23,12 +26,40 @@ func (e ExitCode) Error() string {
 }
 
 // Row represents the state of a row after a call to rows.Next().
+//
+// The mapper is first called on an empty Row to collect the fields and a
+// destination for each of them, so that every row is scanned with a single
+// rows.Scan. Each time the mapper is called on a row after that, the Row
+// methods copy the values out of those destinations.
 type Row struct {
-	rows    *sql.Rows
-	index   int
-	fields  []Field
-	dest    []interface{}
-	tmpdest []interface{}
+	rows   *sql.Rows
+	index  int
+	fields []Field
+	dest   []interface{}
+}
+
+// scan scans the current row into the destinations of the Row. If the row
+// fails to scan, the destinations are scanned again one at a time to find the
+// field that failed, and the error is returned as a rowError of its index.
+func (r *Row) scan() error {
+	err := r.rows.Scan(r.dest...)
+	if err == nil {
+		return nil
+	}
+	var discard interface{}
+	dest := make([]interface{}, len(r.dest))
+	for i := range dest {
+		dest[i] = &discard
+	}
+	for i := range r.dest {
+		dest[i] = r.dest[i]
+		e := r.rows.Scan(dest...)
+		dest[i] = &discard
+		if e != nil {
+			return &rowError{index: i, err: err}
+		}
+	}
+	return err
 }
 
 /* custom */
@@ -40,18 +71,22 @@ func (r *Row) ScanInto(dest interface{}, field Field) {
 		switch dest.(type) {
 		case *bool, *sql.NullBool:
 			r.dest = append(r.dest, &sql.NullBool{})
-		case *float64, *sql.NullFloat64:
+		case *float32, *float64, *sql.NullFloat64:
 			r.dest = append(r.dest, &sql.NullFloat64{})
 		case *int32, *sql.NullInt32:
 			r.dest = append(r.dest, &sql.NullInt32{})
-		case *int, *int64, *sql.NullInt64:
+		case *int, *int8, *int16, *int64, *sql.NullInt64:
 			r.dest = append(r.dest, &sql.NullInt64{})
+		case *uint, *uint64:
+			r.dest = append(r.dest, &nullUint64{})
+		case *[]byte, *json.RawMessage, *NullBytes:
+			r.dest = append(r.dest, &NullBytes{})
 		case *string, *sql.NullString:
 			r.dest = append(r.dest, &sql.NullString{})
 		case *time.Time, *sql.NullTime:
 			r.dest = append(r.dest, &sql.NullTime{})
 		default:
-			r.dest = append(r.dest, dest)
+			r.dest = append(r.dest, newDest(dest))
 		}
 		return
 	}
@@ -62,6 +97,9 @@ func (r *Row) ScanInto(dest interface{}, field Field) {
 	case *sql.NullBool:
 		nullbool := r.dest[r.index].(*sql.NullBool)
 		*ptr = *nullbool
+	case *float32:
+		nullfloat64 := r.dest[r.index].(*sql.NullFloat64)
+		*ptr = toFloat32(r.index, nullfloat64.Float64)
 	case *float64:
 		nullfloat64 := r.dest[r.index].(*sql.NullFloat64)
 		*ptr = nullfloat64.Float64
@@ -71,6 +109,12 @@ func (r *Row) ScanInto(dest interface{}, field Field) {
 	case *int:
 		nullint64 := r.dest[r.index].(*sql.NullInt64)
 		*ptr = int(nullint64.Int64)
+	case *int8:
+		nullint64 := r.dest[r.index].(*sql.NullInt64)
+		*ptr = int8(checkInt(r.index, nullint64.Int64, math.MinInt8, math.MaxInt8, "int8"))
+	case *int16:
+		nullint64 := r.dest[r.index].(*sql.NullInt64)
+		*ptr = int16(checkInt(r.index, nullint64.Int64, math.MinInt16, math.MaxInt16, "int16"))
 	case *int32:
 		nullint32 := r.dest[r.index].(*sql.NullInt32)
 		*ptr = nullint32.Int32
@@ -83,6 +127,21 @@ func (r *Row) ScanInto(dest interface{}, field Field) {
 	case *sql.NullInt64:
 		nullint64 := r.dest[r.index].(*sql.NullInt64)
 		*ptr = *nullint64
+	case *uint:
+		nulluint64 := r.dest[r.index].(*nullUint64)
+		*ptr = toUint(r.index, nulluint64.Uint64)
+	case *uint64:
+		nulluint64 := r.dest[r.index].(*nullUint64)
+		*ptr = nulluint64.Uint64
+	case *[]byte:
+		nullbytes := r.dest[r.index].(*NullBytes)
+		*ptr = nullbytes.Bytes
+	case *json.RawMessage:
+		nullbytes := r.dest[r.index].(*NullBytes)
+		*ptr = nullbytes.Bytes
+	case *NullBytes:
+		nullbytes := r.dest[r.index].(*NullBytes)
+		*ptr = *nullbytes
 	case *string:
 		nullstring := r.dest[r.index].(*sql.NullString)
 		*ptr = nullstring.String
@@ -96,24 +155,64 @@ func (r *Row) ScanInto(dest interface{}, field Field) {
 		nulltime := r.dest[r.index].(*sql.NullTime)
 		*ptr = *nulltime
 	default:
-		var nothing interface{}
-		if len(r.tmpdest) != len(r.dest) {
-			r.tmpdest = make([]interface{}, len(r.dest))
-			for i := range r.tmpdest {
-				r.tmpdest[i] = &nothing
-			}
-		}
-		r.tmpdest[r.index] = dest
-		err := r.rows.Scan(r.tmpdest...)
-		if err != nil {
-			_, sourcefile, linenbr, _ := runtime.Caller(1)
-			panic(fmt.Errorf("row.ScanInto failed on %s:%d: %w", sourcefile, linenbr, err))
-		}
-		r.tmpdest[r.index] = &nothing
+		moveDest(r.index, dest, r.dest[r.index])
 	}
 	r.index++
 }
 
+// newDest returns a new pointer of the same type as dest for rows.Scan to
+// scan into. dest itself cannot be scanned into because the mapper may pass
+// a different pointer every time it is called. If dest is not a plain pointer
+// i.e. it is an sql.Scanner (such as pq.Array(&x)) that may hold state a new
+// value of its type would not have, or it is not a pointer at all, dest itself
+// is returned so that rows.Scan scans into it directly.
+func newDest(dest interface{}) interface{} {
+	if _, ok := dest.(sql.Scanner); ok {
+		return dest
+	}
+	typ := reflect.TypeOf(dest)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		// not a pointer, leave it to rows.Scan to report the error
+		return dest
+	}
+	return reflect.New(typ.Elem()).Interface()
+}
+
+// moveDest copies the value that was scanned into src (created by newDest)
+// into dest, then resets src to its zero value so that no state is carried
+// over to the next row. If src is an sql.Scanner, it was dest itself and
+// rows.Scan has already scanned into it, so nothing is copied. It panics if
+// dest is not a pointer of the same type as src (or the same sql.Scanner),
+// which happens when the mapper passes something different to ScanInto than
+// it did the first time it was called. index is the index of the field that
+// src is the destination of.
+func moveDest(index int, dest, src interface{}) {
+	if _, ok := src.(sql.Scanner); ok {
+		if !isSameDest(dest, src) {
+			panic(&rowError{index: index, err: fmt.Errorf("cannot scan into %T, the mapper must pass the same sql.Scanner every time it is called", dest)})
+		}
+		return
+	}
+	dst, val := reflect.ValueOf(dest), reflect.ValueOf(src)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() || dst.Type() != val.Type() {
+		panic(&rowError{index: index, err: fmt.Errorf("cannot scan into %T, the mapper was first called with %T", dest, src)})
+	}
+	dst.Elem().Set(val.Elem())
+	val.Elem().Set(reflect.Zero(val.Elem().Type()))
+}
+
+// isSameDest reports whether dest and src are the same scan destination.
+func isSameDest(dest, src interface{}) bool {
+	dst, val := reflect.ValueOf(dest), reflect.ValueOf(src)
+	if !dst.IsValid() || dst.Type() != val.Type() {
+		return false
+	}
+	if dst.Kind() == reflect.Ptr {
+		return dst.Pointer() == val.Pointer()
+	}
+	return dst.Type().Comparable() && dest == src
+}
+
 /* bool */
 
 // Bool returns the bool value of the Predicate. BooleanFields are considered
@@ -171,6 +270,20 @@ func (r *Row) NullFloat64(field NumberField) sql.NullFloat64 {
 	return *nullfloat64
 }
 
+/* float32 */
+
+// Float32 returns the float32 value of the NumberField. It panics if the value
+// overflows a float32.
+func (r *Row) Float32(field NumberField) float32 {
+	return toFloat32(r.index-1, r.NullFloat64(field).Float64)
+}
+
+// Float32Valid returns the bool value indicating if the NumberField is
+// non-NULL.
+func (r *Row) Float32Valid(field NumberField) bool {
+	return r.NullFloat64(field).Valid
+}
+
 /* int */
 
 // Int returns the int value of the NumberField.
@@ -183,6 +296,32 @@ func (r *Row) IntValid(field NumberField) bool {
 	return r.NullInt64(field).Valid
 }
 
+/* int8 */
+
+// Int8 returns the int8 value of the NumberField. It panics if the value
+// overflows an int8.
+func (r *Row) Int8(field NumberField) int8 {
+	return int8(checkInt(r.index-1, r.NullInt64(field).Int64, math.MinInt8, math.MaxInt8, "int8"))
+}
+
+// Int8Valid returns the bool value indicating if the NumberField is non-NULL.
+func (r *Row) Int8Valid(field NumberField) bool {
+	return r.NullInt64(field).Valid
+}
+
+/* int16 */
+
+// Int16 returns the int16 value of the NumberField. It panics if the value
+// overflows an int16.
+func (r *Row) Int16(field NumberField) int16 {
+	return int16(checkInt(r.index-1, r.NullInt64(field).Int64, math.MinInt16, math.MaxInt16, "int16"))
+}
+
+// Int16Valid returns the bool value indicating if the NumberField is non-NULL.
+func (r *Row) Int16Valid(field NumberField) bool {
+	return r.NullInt64(field).Valid
+}
+
 /* int64 */
 
 // Int64 returns the int64 value of the NumberField.
@@ -207,6 +346,45 @@ func (r *Row) NullInt64(field NumberField) sql.NullInt64 {
 	return *nullint64
 }
 
+/* uint */
+
+// Uint returns the uint value of the NumberField. It panics if the value
+// overflows a uint, and negative values fail to scan.
+func (r *Row) Uint(field NumberField) uint {
+	return toUint(r.index-1, r.nullUint64(field).Uint64)
+}
+
+// UintValid returns the bool value indicating if the NumberField is non-NULL.
+func (r *Row) UintValid(field NumberField) bool {
+	return r.nullUint64(field).Valid
+}
+
+/* uint64 */
+
+// Uint64 returns the uint64 value of the NumberField. Negative values fail to
+// scan.
+func (r *Row) Uint64(field NumberField) uint64 {
+	return r.nullUint64(field).Uint64
+}
+
+// Uint64Valid returns the bool value indicating if the NumberField is
+// non-NULL.
+func (r *Row) Uint64Valid(field NumberField) bool {
+	return r.nullUint64(field).Valid
+}
+
+// nullUint64 returns the nullUint64 value of the NumberField.
+func (r *Row) nullUint64(field NumberField) nullUint64 {
+	if r.rows == nil {
+		r.fields = append(r.fields, field)
+		r.dest = append(r.dest, &nullUint64{})
+		return nullUint64{}
+	}
+	nulluint64 := r.dest[r.index].(*nullUint64)
+	r.index++
+	return *nulluint64
+}
+
 /* string */
 
 // String returns the string value of the StringField.
@@ -255,3 +433,151 @@ func (r *Row) NullTime(field TimeField) sql.NullTime {
 	r.index++
 	return *nulltime
 }
+
+/* []byte */
+
+// Bytes returns the []byte value of the BinaryField.
+func (r *Row) Bytes(field BinaryField) []byte {
+	return r.NullBytes(field).Bytes
+}
+
+// BytesValid returns the bool value indicating if the BinaryField is
+// non-NULL.
+func (r *Row) BytesValid(field BinaryField) bool {
+	return r.NullBytes(field).Valid
+}
+
+// NullBytes returns the NullBytes value of the BinaryField.
+func (r *Row) NullBytes(field BinaryField) NullBytes {
+	return r.nullBytes(field)
+}
+
+// nullBytes returns the NullBytes value of the field.
+func (r *Row) nullBytes(field Field) NullBytes {
+	if r.rows == nil {
+		r.fields = append(r.fields, field)
+		r.dest = append(r.dest, &NullBytes{})
+		return NullBytes{}
+	}
+	nullbytes := r.dest[r.index].(*NullBytes)
+	r.index++
+	return *nullbytes
+}
+
+/* JSON */
+
+// JSON unmarshals the value of the JSONField into dest, where dest is a
+// pointer. dest is reset to its zero value first so that nothing is left over
+// from the previous row, and stays that way if the JSONField is NULL. It
+// panics if the value cannot be unmarshalled into dest.
+func (r *Row) JSON(field JSONField, dest interface{}) {
+	nullbytes := r.nullBytes(field)
+	if r.rows == nil {
+		return
+	}
+	if rv := reflect.ValueOf(dest); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+	}
+	if !nullbytes.Valid {
+		return
+	}
+	err := json.Unmarshal(nullbytes.Bytes, dest)
+	if err != nil {
+		panic(&rowError{index: r.index - 1, err: fmt.Errorf("row.JSON failed to unmarshal %s: %w", field.GetName(), err)})
+	}
+}
+
+// RawMessage returns the json.RawMessage value of the JSONField. It is nil if
+// the JSONField is NULL.
+func (r *Row) RawMessage(field JSONField) json.RawMessage {
+	return r.nullBytes(field).Bytes
+}
+
+// NullBytes represents a []byte that may be NULL. It implements the
+// sql.Scanner interface so it can be used as a scan destination.
+type NullBytes struct {
+	Bytes []byte
+	Valid bool // Valid is true if Bytes is not NULL
+}
+
+// Scan implements the sql.Scanner interface. The bytes are copied, so they
+// remain valid after the next call to rows.Next().
+func (n *NullBytes) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		n.Bytes, n.Valid = nil, false
+	case []byte:
+		n.Bytes, n.Valid = make([]byte, len(v)), true
+		copy(n.Bytes, v)
+	case string:
+		n.Bytes, n.Valid = []byte(v), true
+	default:
+		return fmt.Errorf("cannot scan %T into NullBytes", value)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullBytes) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Bytes, nil
+}
+
+// nullUint64 is the scan destination of unsigned integers, which may not fit
+// in an int64.
+type nullUint64 struct {
+	Uint64 uint64
+	Valid  bool
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *nullUint64) Scan(value interface{}) error {
+	var err error
+	n.Uint64, n.Valid = 0, value != nil
+	switch v := value.(type) {
+	case nil:
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("%d overflows uint64", v)
+		}
+		n.Uint64 = uint64(v)
+	case uint64:
+		n.Uint64 = v
+	case []byte:
+		n.Uint64, err = strconv.ParseUint(string(v), 10, 64)
+	case string:
+		n.Uint64, err = strconv.ParseUint(v, 10, 64)
+	default:
+		err = fmt.Errorf("cannot scan %T into uint64", value)
+	}
+	return err
+}
+
+// checkInt returns n, or panics if n overflows the integer type typ whose
+// range is [min, max]. index is the index of the field that n is from.
+func checkInt(index int, n, min, max int64, typ string) int64 {
+	if n < min || n > max {
+		panic(&rowError{index: index, err: fmt.Errorf("%d overflows %s", n, typ)})
+	}
+	return n
+}
+
+// toUint converts n to a uint, or panics if n overflows a uint. index is the
+// index of the field that n is from.
+func toUint(index int, n uint64) uint {
+	if uint64(uint(n)) != n {
+		panic(&rowError{index: index, err: fmt.Errorf("%d overflows uint", n)})
+	}
+	return uint(n)
+}
+
+// toFloat32 converts f to a float32, or panics if f overflows a float32.
+// index is the index of the field that f is from.
+func toFloat32(index int, f float64) float32 {
+	if !math.IsInf(f, 0) && math.Abs(f) > math.MaxFloat32 {
+		panic(&rowError{index: index, err: fmt.Errorf("%g overflows float32", f)})
+	}
+	return float32(f)
+}
@@ -4,9 +4,14 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -34,6 +39,7 @@ func init() {
 	MYSQL_PORT := os.Getenv("MYSQL_PORT")
 	MYSQL_NAME := os.Getenv("MYSQL_NAME")
 	txdb.Register("txdb", "mysql", fmt.Sprintf("%s:%s@tcp(127.0.0.1:%s)/%s?parseTime=true", MYSQL_USER, MYSQL_PASSWORD, MYSQL_PORT, MYSQL_NAME))
+	sql.Register("widerows", wideRowsDriver{})
 }
 
 type Option struct {
@@ -308,3 +314,353 @@ func TestRow_Assorted(t *testing.T) {
 	is.Equal(data.wantStringValid, data.gotStringValid)
 	is.True(data.gotTimeValid)
 }
+
+// wideRowsDriver is a database/sql driver that returns rows without hitting a
+// database, so that the scanning done by Row can be tested and benchmarked on
+// its own. The data source name is the number of rows, and the columns are
+// the ones selected by the query: id columns are integers and json columns
+// are JSON objects.
+type wideRowsDriver struct{}
+
+const wideJSONColumns = 8
+
+func (wideRowsDriver) Open(name string) (driver.Conn, error) {
+	rowcount, err := strconv.Atoi(name)
+	return wideRowsConn{rowcount: rowcount}, err
+}
+
+type wideRowsConn struct{ rowcount int }
+
+func (c wideRowsConn) Prepare(query string) (driver.Stmt, error) {
+	return wideRowsStmt{query: query, rowcount: c.rowcount}, nil
+}
+func (c wideRowsConn) Close() error              { return nil }
+func (c wideRowsConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type wideRowsStmt struct {
+	query    string
+	rowcount int
+}
+
+func (s wideRowsStmt) Close() error  { return nil }
+func (s wideRowsStmt) NumInput() int { return -1 }
+func (s wideRowsStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+// Query returns rows with the columns listed in 'SELECT column, ... FROM'.
+func (s wideRowsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	list := strings.TrimPrefix(s.query, "SELECT ")
+	if i := strings.Index(list, " FROM "); i >= 0 {
+		list = list[:i]
+	}
+	var columns []string
+	for _, column := range strings.Split(list, ", ") {
+		columns = append(columns, column[strings.LastIndex(column, ".")+1:])
+	}
+	return &wideRows{columns: columns, rowcount: s.rowcount}, nil
+}
+
+type wideRows struct {
+	columns     []string
+	i, rowcount int
+}
+
+func (rows *wideRows) Columns() []string { return rows.columns }
+func (rows *wideRows) Close() error      { return nil }
+
+// ColumnTypeDatabaseTypeName returns the type of the column the way the MySQL
+// driver does, which returns the VARCHAR name columns as []byte.
+func (rows *wideRows) ColumnTypeDatabaseTypeName(index int) string {
+	switch column := rows.columns[index]; {
+	case strings.HasPrefix(column, "name"):
+		return "VARCHAR"
+	case strings.HasPrefix(column, "json"):
+		return "JSON"
+	default:
+		return "BIGINT"
+	}
+}
+
+// Next returns the i-th row, where the id columns are i and the other columns
+// contain i somewhere.
+func (rows *wideRows) Next(dest []driver.Value) error {
+	if rows.i >= rows.rowcount {
+		return io.EOF
+	}
+	i := strconv.Itoa(rows.i)
+	for j, column := range rows.columns {
+		switch {
+		case strings.HasPrefix(column, "name"):
+			dest[j] = []byte("Name " + i)
+		case strings.HasPrefix(column, "json"):
+			dest[j] = []byte(`{"key_` + i + `": ` + i + `, "name": "lorem ipsum dolor sit amet", "tags": ["a", "b", "c"]}`)
+		default:
+			dest[j] = int64(rows.i)
+		}
+	}
+	rows.i++
+	return nil
+}
+
+// wideQuery is the query selecting the id, json and array columns of the
+// wide rows.
+func wideQuery() string {
+	columns := []string{"id"}
+	for i := 0; i < wideJSONColumns; i++ {
+		columns = append(columns, "json"+strconv.Itoa(i))
+	}
+	return "SELECT " + strings.Join(columns, ", ") + " FROM wide"
+}
+
+type wideRow struct {
+	ID   int
+	JSON [wideJSONColumns]wideJSON
+}
+
+// wideJSON is a sql.Scanner, so it is scanned into directly and has to
+// unmarshal every row into a new map for no state to leak across rows.
+type wideJSON map[string]interface{}
+
+func (j *wideJSON) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("wideJSON: unsupported type %T", value)
+	}
+	*j = nil
+	return json.Unmarshal(b, j)
+}
+
+func (w *wideRow) RowMapper(row *Row) {
+	tbl := &TableInfo{Name: "wide"}
+	w.ID = row.Int(NewNumberField("id", tbl))
+	for i := range w.JSON {
+		row.ScanInto(&w.JSON[i], NewJSONField("json"+strconv.Itoa(i), tbl))
+	}
+}
+
+func TestRow_ScanOnce(t *testing.T) {
+	is := is.New(t)
+	db, err := sql.Open("widerows", "3")
+	is.NoErr(err)
+	defer db.Close()
+	w := &wideRow{}
+	var ws []wideRow
+	err = From(&TableInfo{Name: "wide"}).
+		Selectx(w.RowMapper, func() { ws = append(ws, *w) }).
+		Fetch(db)
+	is.NoErr(err)
+	is.Equal(3, len(ws))
+	for i, w := range ws {
+		is.Equal(i, w.ID)
+		for _, j := range w.JSON {
+			is.Equal(3, len(j)) // no keys left over from the previous rows
+			is.Equal(float64(i), j["key_"+strconv.Itoa(i)])
+		}
+	}
+}
+
+// offsetScanner is a sql.Scanner whose state (the offset) would be lost if it
+// was replaced by a new offsetScanner.
+type offsetScanner struct {
+	offset, n int64
+}
+
+func (s *offsetScanner) Scan(value interface{}) error {
+	n, ok := value.(int64)
+	if !ok {
+		return fmt.Errorf("offsetScanner: unsupported type %T", value)
+	}
+	s.n = s.offset + n
+	return nil
+}
+
+func TestRow_ScanIntoDest(t *testing.T) {
+	is := is.New(t)
+	db, err := sql.Open("widerows", "3")
+	is.NoErr(err)
+	defer db.Close()
+	tbl := &TableInfo{Name: "wide"}
+	id := NewNumberField("id", tbl)
+
+	// sql.Scanners are scanned into directly
+	s := &offsetScanner{offset: 100}
+	var ns []int64
+	err = Selectx(func(row *Row) {
+		row.ScanInto(s, id)
+	}, func() {
+		ns = append(ns, s.n)
+	}).From(tbl).Fetch(db)
+	is.NoErr(err)
+	is.Equal([]int64{100, 101, 102}, ns)
+
+	// a different sql.Scanner than the first time the mapper was called
+	err = Selectx(func(row *Row) {
+		row.ScanInto(&offsetScanner{}, id)
+	}, func() {}).From(tbl).Fetch(db)
+	var qerr *QueryError
+	is.True(errors.As(err, &qerr))
+	is.Equal(0, qerr.Index)
+
+	// a pointer of a different type than the first time the mapper was called
+	type myInt int64
+	type myFloat float64
+	var calls int
+	err = Selectx(func(row *Row) {
+		calls++
+		if calls < 3 {
+			var n myInt
+			row.ScanInto(&n, id)
+		} else {
+			var f myFloat
+			row.ScanInto(&f, id)
+		}
+	}, func() {}).From(tbl).Fetch(db)
+	qerr = nil
+	is.True(errors.As(err, &qerr))
+	is.Equal(0, qerr.Index)
+	is.True(strings.Contains(err.Error(), "cannot scan into *sq.myFloat"))
+}
+
+// BenchmarkRow_ScanOnce fetches wide rows with Row, which scans each row
+// once.
+func BenchmarkRow_ScanOnce(b *testing.B) {
+	db, err := sql.Open("widerows", "100")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+	w := &wideRow{}
+	q := From(&TableInfo{Name: "wide"}).Selectx(w.RowMapper, func() {})
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err := q.Fetch(db); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkRow_RescanPerField fetches the same wide rows the way Row used to,
+// scanning the whole row again for every JSON column. It is the
+// baseline for BenchmarkRow_ScanOnce.
+func BenchmarkRow_RescanPerField(b *testing.B) {
+	db, err := sql.Open("widerows", "100")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+	w := &wideRow{}
+	var nothing interface{}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		rows, err := db.Query(wideQuery())
+		if err != nil {
+			b.Fatal(err)
+		}
+		id := &sql.NullInt64{}
+		dest := []interface{}{id}
+		tmpdest := []interface{}{&nothing}
+		for i := range w.JSON {
+			dest = append(dest, &w.JSON[i])
+			tmpdest = append(tmpdest, &nothing)
+		}
+		for rows.Next() {
+			if err := rows.Scan(dest...); err != nil {
+				b.Fatal(err)
+			}
+			w.ID = int(id.Int64)
+			for i := range w.JSON {
+				tmpdest[1+i] = &w.JSON[i]
+				if err := rows.Scan(tmpdest...); err != nil {
+					b.Fatal(err)
+				}
+				tmpdest[1+i] = &nothing
+			}
+		}
+		if err := rows.Close(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRow_ScalarTypes(t *testing.T) {
+	is := is.New(t)
+	db, err := sql.Open("widerows", "2")
+	is.NoErr(err)
+	defer db.Close()
+	type Scalars struct {
+		Int8, ScanInt8       int8
+		Int16, ScanInt16     int16
+		Uint, ScanUint       uint
+		Uint64, ScanUint64   uint64
+		Float32, ScanFloat32 float32
+		Bytes, ScanBytes     []byte
+		RawMessage           json.RawMessage
+		ScanRawMessage       json.RawMessage
+		JSON                 map[string]interface{}
+	}
+	tbl := &TableInfo{Name: "wide"}
+	id, data := NewNumberField("id", tbl), NewJSONField("json0", tbl)
+	s := &Scalars{}
+	var ss []Scalars
+	err = From(tbl).
+		Selectx(func(row *Row) {
+			s.Int8, s.Int16, s.Uint, s.Uint64, s.Float32 = row.Int8(id), row.Int16(id), row.Uint(id), row.Uint64(id), row.Float32(id)
+			row.ScanInto(&s.ScanInt8, id)
+			row.ScanInto(&s.ScanInt16, id)
+			row.ScanInto(&s.ScanUint, id)
+			row.ScanInto(&s.ScanUint64, id)
+			row.ScanInto(&s.ScanFloat32, id)
+			s.Bytes = row.Bytes(NewBinaryField("json0", tbl))
+			row.ScanInto(&s.ScanBytes, data)
+			s.RawMessage = row.RawMessage(data)
+			row.ScanInto(&s.ScanRawMessage, data)
+			row.JSON(data, &s.JSON)
+		}, func() { ss = append(ss, *s) }).
+		Fetch(db)
+	is.NoErr(err)
+	is.Equal(2, len(ss))
+	for i, s := range ss {
+		is.Equal(int8(i), s.Int8)
+		is.Equal(int8(i), s.ScanInt8)
+		is.Equal(int16(i), s.Int16)
+		is.Equal(int16(i), s.ScanInt16)
+		is.Equal(uint(i), s.Uint)
+		is.Equal(uint(i), s.ScanUint)
+		is.Equal(uint64(i), s.Uint64)
+		is.Equal(uint64(i), s.ScanUint64)
+		is.Equal(float32(i), s.Float32)
+		is.Equal(float32(i), s.ScanFloat32)
+		is.Equal(s.Bytes, s.ScanBytes)
+		is.Equal(json.RawMessage(s.Bytes), s.RawMessage)
+		is.Equal(s.RawMessage, s.ScanRawMessage)
+		is.Equal(3, len(s.JSON))
+		is.Equal(float64(i), s.JSON["key_"+strconv.Itoa(i)])
+	}
+	is.True(string(ss[0].Bytes) != string(ss[1].Bytes)) // not overwritten by the next row
+}
+
+func TestRow_Overflow(t *testing.T) {
+	is := is.New(t)
+	func() {
+		defer func() { is.True(recover() != nil) }()
+		checkInt(0, 128, math.MinInt8, math.MaxInt8, "int8")
+	}()
+	func() {
+		defer func() { is.True(recover() != nil) }()
+		toFloat32(0, math.MaxFloat64)
+	}()
+	is.Equal(int64(-32768), checkInt(0, -32768, math.MinInt16, math.MaxInt16, "int16"))
+	is.Equal(float32(math.Inf(1)), toFloat32(0, math.Inf(1)))
+	var n nullUint64
+	is.True(n.Scan(int64(-1)) != nil)
+	is.NoErr(n.Scan([]byte("18446744073709551615")))
+	is.Equal(nullUint64{Uint64: math.MaxUint64, Valid: true}, n)
+	is.NoErr(n.Scan(nil))
+	is.Equal(nullUint64{}, n)
+	var b NullBytes
+	is.NoErr(b.Scan([]byte{}))
+	is.Equal(NullBytes{Bytes: []byte{}, Valid: true}, b)
+	is.NoErr(b.Scan(nil))
+	is.Equal(NullBytes{}, b)
+}
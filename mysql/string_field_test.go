@@ -287,3 +287,82 @@ func TestStringField_Predicates(t *testing.T) {
 		})
 	}
 }
+
+func TestStringField_Functions(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	name := NewStringField("name", &TableInfo{Schema: "devlab", Name: "users"})
+	email := NewStringField("email", &TableInfo{Schema: "devlab", Name: "users"})
+	tests := []TT{
+		{"Lower", Lower(email), nil, "LOWER(users.email)", nil},
+		{"Upper", email.Upper(), nil, "UPPER(users.email)", nil},
+		{"Trim", name.Trim(), nil, "TRIM(users.name)", nil},
+		{"LTrim", name.LTrim(), nil, "LTRIM(users.name)", nil},
+		{"RTrim", name.RTrim(), nil, "RTRIM(users.name)", nil},
+		{"Length", name.Length(), nil, "CHAR_LENGTH(users.name)", nil},
+		{"Substring", name.Substring(2, 3), nil, "SUBSTRING(users.name FROM ? FOR ?)", []interface{}{2, 3}},
+		{"Concat", Concat(name, String(" "), email), nil, "CONCAT(users.name, ?, users.email)", []interface{}{" "}},
+		{"Replace", name.Replace("a", "b"), nil, "REPLACE(users.name, ?, ?)", []interface{}{"a", "b"}},
+		{"Left", name.Left(3), nil, "LEFT(users.name, ?)", []interface{}{3}},
+		{"Right", name.Right(3), nil, "RIGHT(users.name, ?)", []interface{}{3}},
+		{"Position", email.Position("@"), nil, "POSITION(? IN users.email)", []interface{}{"@"}},
+		{"LPad", name.LPad(8, "*"), nil, "LPAD(users.name, ?, ?)", []interface{}{8, "*"}},
+		{"RPad", name.RPad(8, "*"), nil, "RPAD(users.name, ?, ?)", []interface{}{8, "*"}},
+		{"Collate", name.Concat(email).Collate("utf8mb4_bin"), nil, "CONCAT(users.name, users.email) COLLATE `utf8mb4_bin`", nil},
+		{"excludedTableQualifiers", email.Lower(), []string{"users"}, "LOWER(email)", nil},
+		{"predicate", email.Lower().EqString("bob@email.com"), nil, "LOWER(users.email) = ?", []interface{}{"bob@email.com"}},
+		{"ORDER BY", email.Lower().Desc(), nil, "LOWER(users.email) DESC", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestStringField_Regexp(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	email := NewStringField("email", &TableInfo{Schema: "devlab", Name: "users"})
+	tests := []TT{
+		{"Regexp", email.Regexp("^bob"), "users.email REGEXP ?", []interface{}{"^bob"}},
+		{"NotRegexp", email.NotRegexp("^bob"), "users.email NOT REGEXP ?", []interface{}{"^bob"}},
+		{"RegexpLike", email.RegexpLike("^BOB", "i"), "REGEXP_LIKE(users.email, ?, ?)", []interface{}{"^BOB", "i"}},
+		{
+			"RegexpReplace",
+			email.RegexpReplace("@.*$", ""),
+			"REGEXP_REPLACE(users.email, ?, ?)",
+			[]interface{}{"@.*$", ""},
+		},
+		{"RegexpSubstring", email.RegexpSubstring("@.*$"), "REGEXP_SUBSTR(users.email, ?)", []interface{}{"@.*$"}},
+		{"RegexpInstr", email.RegexpInstr("@"), "REGEXP_INSTR(users.email, ?)", []interface{}{"@"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
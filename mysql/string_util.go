@@ -2,9 +2,8 @@ package sq
 
 import (
 	"database/sql"
-	"database/sql/driver"
-	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"reflect"
 	"strconv"
@@ -15,15 +14,22 @@ import (
 // ExpandValues will expand each value one by one into successive question mark
 // ? placeholders in the format string, writing the results into the buffer and
 // args slice. It propagates the excludedTableQualifiers down to its child elements.
+// Question marks inside string literals, quoted identifiers and comments are
+// not placeholders, and ?? is an escaped question mark (see nextPlaceholder).
+// If the number of placeholders does not match the number of values, a
+// BuildError is appended to the args instead.
 func ExpandValues(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string, format string, values []interface{}) {
-	for i := strings.Index(format, "?"); i >= 0 && len(values) > 0; i = strings.Index(format, "?") {
+	if count := countPlaceholders(format); count != len(values) {
+		appendBuildError(buf, args, fmt.Errorf("format %q has %d placeholders, but %d values were given", format, count, len(values)))
+		return
+	}
+	for i, escaped := nextPlaceholder(format); i >= 0; i, escaped = nextPlaceholder(format) {
+		if escaped {
+			buf.WriteString(format[:i+2])
+			format = format[i+2:]
+			continue
+		}
 		buf.WriteString(format[:i])
-		// TODO: I don't know if ?? should be unescaped to ?
-		// if len(format[i:]) > 1 && format[i:i+2] == "??" {
-		// 	buf.WriteString("?")
-		// 	format = format[i+2:]
-		// 	continue
-		// }
 		AppendSQLValue(buf, args, excludedTableQualifiers, values[0])
 		format = format[i+1:]
 		values = values[1:]
@@ -96,64 +102,16 @@ func RandomString(n int) string {
 	return sb.String()
 }
 
-// InterpolateSQLValue interpolates an interface value as its SQL
-// representation into a buffer. This makes it vulnerable to SQL injection and
-// should be used for display purposes ONLY, not for actually running against a
-// database.
+// InterpolateSQLValue interpolates an interface value as its SQL literal into
+// a buffer, escaped for MySQL. Prefer bind parameters over interpolated values
+// when running queries against a database. If the value cannot be written as a
+// literal (e.g. its driver.Valuer fails), a ? placeholder is written instead.
 func InterpolateSQLValue(buf *strings.Builder, value interface{}) {
-	switch v := value.(type) {
-	case nil:
-		buf.WriteString("NULL")
-	case bool:
-		if v {
-			buf.WriteString("TRUE")
-		} else {
-			buf.WriteString("FALSE")
-		}
-	case string:
-		buf.WriteString("'")
-		buf.WriteString(v)
-		buf.WriteString("'")
-	case int, int8, int16, int32, int64,
-		uint, uint8, uint16, uint32, uint64,
-		float32, float64:
-		buf.WriteString(fmt.Sprint(value))
-	case time.Time:
-		buf.WriteString("'")
-		buf.WriteString(v.Format(time.RFC3339Nano))
-		buf.WriteString("'")
-	case driver.Valuer:
-		Interface, err := v.Value()
-		if err != nil {
-			buf.WriteString("(")
-			buf.WriteString(err.Error())
-			buf.WriteString(")")
-		} else {
-			switch Concrete := Interface.(type) {
-			case string:
-				buf.WriteString("'")
-				buf.WriteString(Concrete)
-				buf.WriteString("'")
-			case nil:
-				buf.WriteString("NULL")
-			default:
-				buf.WriteString("(")
-				buf.WriteString(fmt.Sprintf("%#v", value)) // give up, don't know what it is, resort to fmt.Sprintf
-				buf.WriteString(")")
-			}
-		}
-	default:
-		b, err := json.Marshal(value)
-		if err != nil {
-			buf.WriteString("(")
-			buf.WriteString(fmt.Sprintf("%#v", value)) // give up, don't know what it is, resort to fmt.Sprintf
-			buf.WriteString(")")
-		} else {
-			buf.WriteString("'")
-			buf.Write(b)
-			buf.WriteString("'")
-		}
+	s, err := literal(value)
+	if err != nil {
+		s = "?"
 	}
+	buf.WriteString(s)
 }
 
 // AppendSQLDisplay marshals an interface value into a buffer.
@@ -201,23 +159,59 @@ func AppendSQLDisplay(buf *strings.Builder, value interface{}) {
 }
 
 // QuestionInterpolate interpolates the question mark ? placeholders in a query
-// string with the args in the args slice. It is vulnerable to SQL injection
-// and should be used for display purposes only, not for actually running
-// against a database.
+// string with the SQL literals of the args in the args slice (see
+// InterpolateSQLValue). Placeholders whose arg cannot be written as a literal
+// are left as they are.
 func QuestionInterpolate(query string, args ...interface{}) string {
+	query, _ = questionInterpolate(query, args)
+	return query
+}
+
+// questionInterpolate is QuestionInterpolate, but it also returns the first
+// error from writing an arg as a literal.
+func questionInterpolate(query string, args []interface{}) (string, error) {
 	buf := &strings.Builder{}
+	var firstErr error
 	// i is the position of the ? in the query
-	for i := strings.Index(query, "?"); i >= 0 && len(args) > 0; i = strings.Index(query, "?") {
+	for i, escaped := nextPlaceholder(query); i >= 0; i, escaped = nextPlaceholder(query) {
+		if !escaped && len(args) == 0 {
+			break
+		}
 		buf.WriteString(query[:i])
-		if len(query[i:]) > 1 && query[i:i+2] == "??" {
+		if escaped {
 			buf.WriteString("?")
 			query = query[i+2:]
 			continue
 		}
-		InterpolateSQLValue(buf, args[0])
+		s, err := literal(args[0])
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			s = "?"
+		}
+		buf.WriteString(s)
 		query = query[i+1:]
 		args = args[1:]
 	}
 	buf.WriteString(query)
-	return buf.String()
+	return buf.String(), firstErr
+}
+
+// hashName returns the prefix followed by a hash of the SQL and its args. It
+// is used to name things that the user has not named, so that the name stays
+// the same every time the SQL is generated. The args are hashed as literals,
+// so that SQL which differs only in its bound values gets a different name.
+func hashName(prefix string, query string, args []interface{}) string {
+	h := fnv.New32a()
+	h.Write([]byte(query))
+	for _, arg := range args {
+		s, err := literal(arg)
+		if err != nil {
+			s = fmt.Sprintf("%#v", arg)
+		}
+		h.Write([]byte{0})
+		h.Write([]byte(s))
+	}
+	return prefix + strconv.FormatUint(uint64(h.Sum32()), 36)
 }
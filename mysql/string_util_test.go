@@ -2,6 +2,7 @@ package sq
 
 import (
 	"database/sql/driver"
+	"errors"
 	"strconv"
 	"strings"
 	"testing"
@@ -44,8 +45,24 @@ func TestInterpolateSQLValue(t *testing.T) {
 		func() TT {
 			desc := "time"
 			now := time.Now()
-			return TT{desc, now, "'" + now.Format(time.RFC3339Nano) + "'"}
+			return TT{desc, now, "'" + now.Format("2006-01-02 15:04:05.999999-07:00") + "'"}
 		}(),
+		{
+			"time with time zone",
+			time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.FixedZone("", 8*60*60)),
+			"'2020-01-02 03:04:05.6+08:00'",
+		},
+		{"escaped string", `it's a \path`, `'it''s a \\path'`},
+		{"control characters", "a\nb\x00", `'a\nb\0'`},
+		{"bytes", []byte{0xde, 0xad, 0xbe, 0xef}, "X'deadbeef'"},
+		{"nil bytes", []byte(nil), "NULL"},
+		{"float", 1.5, "1.5"},
+		{"negative int", -5, "(-5)"},
+		{"negative float", -1.5, "(-1.5)"},
+		{"named string", JoinType("x"), "'x'"},
+		{"pointer", func() *int { i := 5; return &i }(), "5"},
+		{"nil pointer", (*int)(nil), "NULL"},
+		{"slice", []int{1, 2}, "'[1,2]'"},
 		{"driver.Valuer value", valuer{a: 3, b: 4}, "'7'"},
 		{"driver.Valuer nil", valuer{a: 0, b: 0}, "NULL"},
 		{
@@ -65,3 +82,83 @@ func TestInterpolateSQLValue(t *testing.T) {
 		})
 	}
 }
+
+func TestToSQLInline(t *testing.T) {
+	type TT struct {
+		description string
+		q           interface{ ToSQLInline() (string, error) }
+		want        string
+	}
+	u := USERS().As("u")
+	tests := []TT{
+		{
+			"SelectQuery",
+			Select(u.USER_ID).From(u).Where(u.DISPLAYNAME.EqString(`it's \`), u.USER_ID.GtInt(5)),
+			`SELECT u.user_id FROM devlab.users AS u WHERE u.displayname = 'it''s \\' AND u.user_id > 5`,
+		},
+		{
+			"InsertQuery",
+			InsertInto(u).Columns(u.DISPLAYNAME, u.EMAIL).Values("a", "a@email.com"),
+			"INSERT INTO devlab.users (displayname, email) VALUES ('a', 'a@email.com')",
+		},
+		{
+			"UpdateQuery",
+			Update(u).Set(u.DISPLAYNAME.SetString("b")).Where(u.USER_ID.EqInt(1)),
+			"UPDATE devlab.users AS u SET u.displayname = 'b' WHERE u.user_id = 1",
+		},
+		{
+			"DeleteQuery",
+			DeleteFrom(USERS()).Where(USERS().USER_ID.EqInt(1)),
+			"DELETE FROM devlab.users WHERE users.user_id = 1",
+		},
+		{
+			"VariadicQuery",
+			Union(Select(u.USER_ID).From(u).Where(u.USER_ID.EqInt(1)), Select(u.USER_ID).From(u).Where(u.USER_ID.EqInt(2))),
+			"SELECT u.user_id FROM devlab.users AS u WHERE u.user_id = 1" +
+				" UNION SELECT u.user_id FROM devlab.users AS u WHERE u.user_id = 2",
+		},
+		{
+			"negated negative value",
+			Select(Int(-5).Neg()).From(u).Where(u.USER_ID.GtInt(-1)),
+			"SELECT -(-5) FROM devlab.users AS u WHERE u.user_id > (-1)",
+		},
+		{
+			"CustomQuery",
+			Queryf("CREATE VIEW v AS SELECT ? AS x, '?' AS y", "a"),
+			"CREATE VIEW v AS SELECT 'a' AS x, '?' AS y",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			got, err := tt.q.ToSQLInline()
+			is.NoErr(err)
+			is.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestToSQLInline_Error(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	errValue := errors.New("cannot be a value")
+	_, err := Select(u.USER_ID).From(u).Where(Predicatef("? = ?", u.EMAIL, errValuer{errValue})).ToSQLInline()
+	is.True(errors.Is(err, errValue))
+	_, err = Queryf("SELECT ?", errValuer{errValue}).ToSQLInline()
+	is.True(errors.Is(err, errValue))
+	var berr *BuildError
+	_, err = Select(u.USER_ID).From(u).Where(Predicatef("? = ?", u.EMAIL)).ToSQLInline()
+	is.True(errors.As(err, &berr))
+	is.Equal("SELECT u.user_id FROM devlab.users AS u WHERE ? = 'a'", QuestionInterpolate("SELECT u.user_id FROM devlab.users AS u WHERE ? = ?", errValuer{errValue}, "a"))
+}
+
+// errValuer is a driver.Valuer that always fails.
+type errValuer struct {
+	err error
+}
+
+func (v errValuer) Value() (driver.Value, error) {
+	return nil, v.err
+}
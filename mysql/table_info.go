@@ -9,6 +9,11 @@ type TableInfo struct {
 	Schema string
 	Name   string
 	Alias  string
+	// CaseSensitive marks the Schema, Name and column names as the exact
+	// names in the database, so that they are quoted if they have any
+	// character that is not allowed in an unquoted identifier. It is set by
+	// sqgen.
+	CaseSensitive bool
 }
 
 // AppendSQLExclude marshals the TableInfo into a buffer and an args slice.
@@ -17,22 +22,10 @@ func (tbl *TableInfo) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 		return
 	}
 	if tbl.Schema != "" {
-		if strings.ContainsAny(tbl.Schema, " \t") {
-			buf.WriteString("`")
-			buf.WriteString(tbl.Schema)
-			buf.WriteString("`.")
-		} else {
-			buf.WriteString(tbl.Schema)
-			buf.WriteString(".")
-		}
-	}
-	if strings.ContainsAny(tbl.Name, " \t") {
-		buf.WriteString("`")
-		buf.WriteString(tbl.Name)
-		buf.WriteString("`")
-	} else {
-		buf.WriteString(tbl.Name)
+		appendIdentifier(buf, tbl.Schema, tbl.CaseSensitive)
+		buf.WriteString(".")
 	}
+	appendIdentifier(buf, tbl.Name, tbl.CaseSensitive)
 }
 
 // GetAlias returns the alias of the TableInfo.
@@ -51,5 +44,10 @@ func (tbl *TableInfo) GetName() string {
 	return tbl.Name
 }
 
+// isCaseSensitive reports whether the TableInfo names are case sensitive.
+func (tbl *TableInfo) isCaseSensitive() bool {
+	return tbl != nil && tbl.CaseSensitive
+}
+
 // AssertBaseTable implements the BaseTable interface.
 func (tbl *TableInfo) AssertBaseTable() {}
@@ -18,6 +18,9 @@ func TestTableInfo_AppendSQL(t *testing.T) {
 		{"empty", nil, "", nil},
 		{"has schema", &TableInfo{Schema: "devlab", Name: "users"}, "devlab.users", nil},
 		{"no schema", &TableInfo{Name: "users"}, "users", nil},
+		{"reserved word", &TableInfo{Schema: "devlab", Name: "order"}, "devlab.`order`", nil},
+		{"case sensitive", &TableInfo{Schema: "devlab", Name: "UserRoles", CaseSensitive: true}, "devlab.UserRoles", nil},
+		{"special characters", &TableInfo{Schema: "devlab", Name: "user-roles", CaseSensitive: true}, "devlab.`user-roles`", nil},
 		{
 			// https://stackoverflow.com/q/506826
 			// only villians put whitespaces in their schema/table/column names >.>
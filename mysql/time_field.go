@@ -5,18 +5,27 @@ import (
 	"time"
 )
 
-// TimeField either represents a time column or a literal time.Time value.
+// TimeField either represents a time column, a time expression or a literal
+// time.Time value.
 type TimeField struct {
 	// TimeField will be one of the following:
 
-	// 1) Literal time.Time value
+	// 1) Time expression
+	// Examples of time expressions:
+	// | query                               | args |
+	// |-------------------------------------|------|
+	// | (SELECT MAX(created_at) FROM users) |      |
+	format *string
+	values []interface{}
+
+	// 2) Literal time.Time value
 	// Examples of literal string values:
 	// | query | args       |
 	// |-------|------------|
 	// | ?     | time.Now() |
 	value *time.Time
 
-	// 2) Time column
+	// 3) Time column
 	// Examples of time columns:
 	// | query            | args |
 	// |------------------|------|
@@ -33,39 +42,16 @@ type TimeField struct {
 // in the TimeField internal struct comments.
 func (f TimeField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
 	switch {
+	case f.format != nil:
+		// 1) Time expression
+		ExpandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
 	case f.value != nil:
-		// 1) Literal time.Time value
+		// 2) Literal time.Time value
 		buf.WriteString("?")
 		*args = append(*args, *f.value)
 	default:
-		// 2) Time column
-		tableQualifier := f.table.GetAlias()
-		if tableQualifier == "" {
-			tableQualifier = f.table.GetName()
-		}
-		for _, excludedTableQualifier := range excludedTableQualifiers {
-			if tableQualifier == excludedTableQualifier {
-				tableQualifier = ""
-				break
-			}
-		}
-		if tableQualifier != "" {
-			if strings.ContainsAny(tableQualifier, " \t") {
-				buf.WriteString("`")
-				buf.WriteString(tableQualifier)
-				buf.WriteString("`.")
-			} else {
-				buf.WriteString(tableQualifier)
-				buf.WriteString(".")
-			}
-		}
-		if strings.ContainsAny(f.name, " \t") {
-			buf.WriteString("`")
-			buf.WriteString(f.name)
-			buf.WriteString("`")
-		} else {
-			buf.WriteString(f.name)
-		}
+		// 3) Time column
+		appendColumn(buf, f.table, f.name, excludedTableQualifiers)
 	}
 	if f.descending != nil {
 		if *f.descending {
@@ -278,6 +264,24 @@ func (f TimeField) NotBetweenTime(start, end time.Time) Predicate {
 	}
 }
 
+// InQuery returns an 'X IN (subquery)' Predicate. The subquery must select
+// exactly one field.
+func (f TimeField) InQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? IN (?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// NotInQuery returns an 'X NOT IN (subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f TimeField) NotInQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? NOT IN (?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
 // String returns the string representation of the TimeField.
 func (f TimeField) String() string {
 	buf := &strings.Builder{}
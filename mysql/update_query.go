@@ -43,9 +43,26 @@ func (q UpdateQuery) ToSQL() (string, []interface{}) {
 	buf := &strings.Builder{}
 	var args []interface{}
 	q.AppendSQL(buf, &args)
+	unescapeQuestionMarks(buf)
 	return buf.String(), args
 }
 
+// ToSQLInline marshals the UpdateQuery into a query string with the values inlined
+// as SQL literals instead of bind parameters. It is meant for where bind
+// parameters are not allowed e.g. in DDL, otherwise use ToSQL. It returns
+// an error if a value cannot be written as a literal.
+func (q UpdateQuery) ToSQLInline() (string, error) {
+	q.Log = nil
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args)
+	query, err := questionInterpolate(buf.String(), args)
+	if err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
 // AppendSQL marshals the UpdateQuery into a buffer and args slice.
 func (q UpdateQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	// WITH
@@ -58,30 +75,18 @@ func (q UpdateQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	if q.UpdateTable == nil {
 		buf.WriteString("NULL")
 	} else {
-		switch v := q.UpdateTable.(type) {
-		case Query:
-			buf.WriteString("(")
-			v.NestThis().AppendSQL(buf, args)
-			buf.WriteString(")")
-		default:
-			q.UpdateTable.AppendSQL(buf, args)
-		}
-		alias := q.UpdateTable.GetAlias()
-		if alias != "" {
-			buf.WriteString(" AS ")
-			buf.WriteString(alias)
-		}
-	}
-	// SET
-	if len(q.Assignments) > 0 {
-		buf.WriteString(" SET ")
-		q.Assignments.AppendSQLExclude(buf, args, nil)
+		appendTable(buf, args, q.UpdateTable)
 	}
 	// JOIN
 	if len(q.JoinTables) > 0 {
 		buf.WriteString(" ")
 		q.JoinTables.AppendSQL(buf, args)
 	}
+	// SET
+	if len(q.Assignments) > 0 {
+		buf.WriteString(" SET ")
+		q.Assignments.AppendSQLExclude(buf, args, nil)
+	}
 	// WHERE
 	if len(q.WherePredicate.Predicates) > 0 {
 		buf.WriteString(" WHERE ")
@@ -134,7 +139,6 @@ func (q UpdateQuery) As(alias string) UpdateQuery {
 func Update(table BaseTable) UpdateQuery {
 	return UpdateQuery{
 		UpdateTable: table,
-		Alias:       RandomString(8),
 	}
 }
 
@@ -284,18 +288,22 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	var tmpargs []interface{}
 	q.LogSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs)
+	unescapeQuestionMarks(tmpbuf)
+	if err = ArgsError(tmpargs); err != nil {
+		return rowsAffected, newQueryError("UPDATE", tmpbuf.String(), tmpargs, nil, err)
+	}
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
 		res, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return rowsAffected, err
+		return rowsAffected, newQueryError("UPDATE", tmpbuf.String(), tmpargs, nil, err)
 	}
 	if res != nil && ErowsAffected&flag != 0 {
 		rowsAffected, err = res.RowsAffected()
 		if err != nil {
-			return rowsAffected, err
+			return rowsAffected, newQueryError("UPDATE", tmpbuf.String(), tmpargs, nil, err)
 		}
 	}
 	return rowsAffected, nil
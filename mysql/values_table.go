@@ -0,0 +1,147 @@
+package sq
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ValuesTable represents a VALUES list that is used as a table i.e.
+// '(VALUES ROW(a, b), ROW(c, d)) AS alias (column1, column2)'. It can be used
+// anywhere a Table is accepted e.g. in FROM or JOIN, including the JOINs of an
+// UPDATE. Table value constructors require MySQL 8.0.19 or later.
+type ValuesTable struct {
+	Alias     string
+	Columns   []string
+	RowValues RowValues
+}
+
+// NewValuesTable returns a new ValuesTable with the alias and column names.
+// Add rows to it with Row.
+func NewValuesTable(alias string, columns ...string) ValuesTable {
+	return ValuesTable{
+		Alias:   alias,
+		Columns: columns,
+	}
+}
+
+// AppendSQL marshals the ValuesTable into a buffer and an args slice. The
+// alias and column list are written by the ValuesTable itself because they
+// have to come after the VALUES list. A ValuesTable that is not a valid VALUES
+// list (see validate) is written as a BuildError.
+func (t ValuesTable) AppendSQL(buf *strings.Builder, args *[]interface{}) {
+	if err := t.validate(); err != nil {
+		appendBuildError(buf, args, err)
+		return
+	}
+	buf.WriteString("(VALUES ")
+	for i, rowvalue := range t.RowValues {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("ROW(")
+		for j, value := range rowvalue {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			if b, ok := value.([]byte); ok {
+				// a []byte is a single BLOB value, not a list of values
+				buf.WriteString("?")
+				*args = append(*args, b)
+				continue
+			}
+			AppendSQLValue(buf, args, nil, value)
+		}
+		buf.WriteString(")")
+	}
+	buf.WriteString(") AS ")
+	appendIdentifier(buf, t.Alias, false)
+	if len(t.Columns) > 0 {
+		buf.WriteString(" (")
+		appendIdentifiers(buf, t.Columns)
+		buf.WriteString(")")
+	}
+}
+
+// validate checks that the ValuesTable has at least one row, that every row
+// has the same number of values as the first row and as there are Columns,
+// and that no value is a slice (which would expand into several values).
+func (t ValuesTable) validate() error {
+	if len(t.RowValues) == 0 {
+		return fmt.Errorf("ValuesTable %s has no rows", t.Alias)
+	}
+	for i, rowvalue := range t.RowValues {
+		switch {
+		case len(rowvalue) == 0:
+			return fmt.Errorf("row %d of ValuesTable %s has no values", i+1, t.Alias)
+		case len(t.Columns) > 0 && len(rowvalue) != len(t.Columns):
+			return fmt.Errorf("row %d of ValuesTable %s has %d values, but there are %d columns", i+1, t.Alias, len(rowvalue), len(t.Columns))
+		case len(rowvalue) != len(t.RowValues[0]):
+			return fmt.Errorf("row %d of ValuesTable %s has %d values, but row 1 has %d", i+1, t.Alias, len(rowvalue), len(t.RowValues[0]))
+		}
+		for j, value := range rowvalue {
+			if _, ok := value.([]byte); ok {
+				continue
+			}
+			if value != nil && reflect.TypeOf(value).Kind() == reflect.Slice {
+				return fmt.Errorf("value %d of row %d of ValuesTable %s is a slice (%T), which cannot be a single value", j+1, i+1, t.Alias, value)
+			}
+		}
+	}
+	return nil
+}
+
+// Row returns a new ValuesTable with the row of values appended to it. The
+// values must be in the same order as the columns.
+func (t ValuesTable) Row(values ...interface{}) ValuesTable {
+	t.RowValues = append(t.RowValues, values)
+	return t
+}
+
+// As returns a new ValuesTable with the new table Alias.
+func (t ValuesTable) As(alias string) ValuesTable {
+	t.Alias = alias
+	return t
+}
+
+// GetAlias implements the Table interface. It always returns an empty string,
+// because the ValuesTable writes its own alias in AppendSQL.
+func (t ValuesTable) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Table interface. It returns the Alias of the
+// ValuesTable, which is what its columns are qualified with.
+func (t ValuesTable) GetName() string {
+	return t.Alias
+}
+
+// NumberField returns the column of the ValuesTable as a NumberField. An
+// unknown column name writes an error into the SQL query.
+func (t ValuesTable) NumberField(name string) NumberField {
+	return derivedNumberField(t, nil, t.Columns, name)
+}
+
+// StringField returns the column of the ValuesTable as a StringField. An
+// unknown column name writes an error into the SQL query.
+func (t ValuesTable) StringField(name string) StringField {
+	return derivedStringField(t, nil, t.Columns, name)
+}
+
+// TimeField returns the column of the ValuesTable as a TimeField. An unknown
+// column name writes an error into the SQL query.
+func (t ValuesTable) TimeField(name string) TimeField {
+	return derivedTimeField(t, nil, t.Columns, name)
+}
+
+// BooleanField returns the column of the ValuesTable as a BooleanField. An
+// unknown column name writes an error into the SQL query.
+func (t ValuesTable) BooleanField(name string) BooleanField {
+	return derivedBooleanField(t, nil, t.Columns, name)
+}
+
+// JSONField returns the column of the ValuesTable as a JSONField. An unknown
+// column name writes an error into the SQL query.
+func (t ValuesTable) JSONField(name string) JSONField {
+	return derivedJSONField(t, nil, t.Columns, name)
+}
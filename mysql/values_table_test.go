@@ -0,0 +1,95 @@
+package sq
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestValuesTable(t *testing.T) {
+	type TT struct {
+		description string
+		q           Query
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := USERS().As("u")
+	v := NewValuesTable("v", "id", "displayname").
+		Row(1, "alice").
+		Row(2, "bob")
+	tests := []TT{
+		{
+			"SELECT FROM",
+			Select(v.NumberField("id"), v.StringField("displayname")).From(v),
+			"SELECT v.id, v.displayname FROM (VALUES ROW(?, ?), ROW(?, ?)) AS v (id, displayname)",
+			[]interface{}{1, "alice", 2, "bob"},
+		},
+		{
+			"JOIN",
+			From(u).Join(v, v.NumberField("id").Eq(u.USER_ID)).Select(u.EMAIL),
+			"SELECT u.email FROM devlab.users AS u JOIN (VALUES ROW(?, ?), ROW(?, ?)) AS v (id, displayname) ON v.id = u.user_id",
+			[]interface{}{1, "alice", 2, "bob"},
+		},
+		{
+			"UPDATE JOIN",
+			Update(u).
+				Join(v, v.NumberField("id").Eq(u.USER_ID)).
+				Set(u.DISPLAYNAME.Set(v.StringField("displayname"))),
+			"UPDATE devlab.users AS u JOIN (VALUES ROW(?, ?), ROW(?, ?)) AS v (id, displayname) ON v.id = u.user_id SET u.displayname = v.displayname",
+			[]interface{}{1, "alice", 2, "bob"},
+		},
+		{
+			"NULL and []byte",
+			From(NewValuesTable("t", "a", "b").Row(nil, []byte("x"))).SelectAll(),
+			"SELECT * FROM (VALUES ROW(NULL, ?)) AS t (a, b)",
+			[]interface{}{[]byte("x")},
+		},
+		{
+			"unknown column",
+			Select(v.NumberField("email")).From(v),
+			"SELECT ? FROM (VALUES ROW(?, ?), ROW(?, ?)) AS v (id, displayname)",
+			[]interface{}{&BuildError{Err: errors.New("unknown column email in v")}, 1, "alice", 2, "bob"},
+		},
+		{
+			"no rows",
+			From(NewValuesTable("t", "id")).SelectAll(),
+			"SELECT * FROM ?",
+			[]interface{}{&BuildError{Err: errors.New("ValuesTable t has no rows")}},
+		},
+		{
+			"empty row",
+			From(NewValuesTable("t", "id").Row(1).Row()).SelectAll(),
+			"SELECT * FROM ?",
+			[]interface{}{&BuildError{Err: errors.New("row 2 of ValuesTable t has no values")}},
+		},
+		{
+			"ragged rows",
+			From(NewValuesTable("t").Row(1, "alice").Row(2)).SelectAll(),
+			"SELECT * FROM ?",
+			[]interface{}{&BuildError{Err: errors.New("row 2 of ValuesTable t has 1 values, but row 1 has 2")}},
+		},
+		{
+			"column count mismatch",
+			From(NewValuesTable("t", "id", "displayname").Row(1, "alice", "bob")).SelectAll(),
+			"SELECT * FROM ?",
+			[]interface{}{&BuildError{Err: errors.New("row 1 of ValuesTable t has 3 values, but there are 2 columns")}},
+		},
+		{
+			"slice value",
+			From(NewValuesTable("t", "id", "ids").Row(1, []int{2, 3})).SelectAll(),
+			"SELECT * FROM ?",
+			[]interface{}{&BuildError{Err: errors.New("value 2 of row 1 of ValuesTable t is a slice ([]int), which cannot be a single value")}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
@@ -1,6 +1,15 @@
 package sq
 
-import "strings"
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // VariadicQueryOperator is an operator that can join a variadic number of
 // queries together.
@@ -22,31 +31,74 @@ type VariadicQuery struct {
 	Nested   bool
 	Alias    string
 	Operator VariadicQueryOperator
-	Queries  []Query
+	// WITH
+	CTEs    CTEs
+	Queries []Query
+	// ORDER BY
+	OrderByFields Fields
+	// LIMIT
+	LimitValue *int64
+	// OFFSET
+	OffsetValue *int64
+	// DB
+	DB          DB
+	Mapper      func(*Row)
+	Accumulator func()
+	// Logging
+	Log     Logger
+	LogFlag LogFlag
+	LogSkip int
 }
 
 // ToSQL marshals the VariadicQuery into a query string and args slice.
 func (q VariadicQuery) ToSQL() (string, []interface{}) {
+	q.LogSkip += 1
 	buf := &strings.Builder{}
 	var args []interface{}
 	q.AppendSQL(buf, &args)
+	unescapeQuestionMarks(buf)
 	return buf.String(), args
 }
 
-// AppendSQL marshals the VariadicQuery into a buffer and args slice.
+// ToSQLInline marshals the VariadicQuery into a query string with the values inlined
+// as SQL literals instead of bind parameters. It is meant for where bind
+// parameters are not allowed e.g. in DDL, otherwise use ToSQL. It returns
+// an error if a value cannot be written as a literal.
+func (q VariadicQuery) ToSQLInline() (string, error) {
+	q.Log = nil
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args)
+	query, err := questionInterpolate(buf.String(), args)
+	if err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
+// AppendSQL marshals the VariadicQuery into a buffer and args slice. If the
+// select lists of the queries do not have the same number of columns, a
+// BuildError is appended to the args in place of the queries.
 func (q VariadicQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	if q.Operator == "" {
 		q.Operator = QueryUnion
 	}
-	switch len(q.Queries) {
-	case 0:
-		break
-	case 1:
-		q.Queries[0].AppendSQL(buf, args)
-	default:
-		if q.Nested {
-			buf.WriteString("(")
-		}
+	// a nested compound query is bracketed as a whole, so that its WITH, ORDER
+	// BY, LIMIT and OFFSET stay with it instead of applying to the enclosing
+	// query
+	bracket := q.Nested && (len(q.Queries) > 1 || len(q.CTEs) > 0 ||
+		len(q.OrderByFields) > 0 || q.LimitValue != nil || q.OffsetValue != nil)
+	if bracket {
+		buf.WriteString("(")
+	}
+	// WITH
+	if len(q.CTEs) > 0 {
+		q.CTEs.AppendSQL(buf, args)
+		buf.WriteString(" ")
+	}
+	if err := q.checkColumnCounts(); err != nil {
+		appendBuildError(buf, args, err)
+	} else if len(q.Queries) > 0 {
 		for i, query := range q.Queries {
 			if i > 0 {
 				buf.WriteString(" ")
@@ -59,14 +111,306 @@ func (q VariadicQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 			case VariadicQuery:
 				v.Nested = true
 				v.AppendSQL(buf, args)
-			default:
+			case SelectQuery:
+				// a query with its own ORDER BY, LIMIT or OFFSET has to be
+				// bracketed so that they do not apply to the combined result
+				bracket := len(v.OrderByFields) > 0 || v.LimitValue != nil || v.OffsetValue != nil
+				if bracket {
+					buf.WriteString("(")
+				}
+				v.Nested = true
 				v.AppendSQL(buf, args)
+				if bracket {
+					buf.WriteString(")")
+				}
+			default:
+				v.NestThis().AppendSQL(buf, args)
+			}
+		}
+	}
+	// ORDER BY
+	if len(q.OrderByFields) > 0 {
+		// the combined result can only be ordered by its output columns, so
+		// the columns of the VariadicQuery itself and of the tables in the
+		// queries are unqualified
+		buf.WriteString(" ORDER BY ")
+		q.OrderByFields.AppendSQLExclude(buf, args, append(q.tableQualifiers(), derivedSource(q)))
+	}
+	// LIMIT
+	if q.LimitValue != nil {
+		buf.WriteString(" LIMIT ?")
+		if *q.LimitValue < 0 {
+			*q.LimitValue = -*q.LimitValue
+		}
+		*args = append(*args, *q.LimitValue)
+	}
+	// OFFSET
+	if q.OffsetValue != nil {
+		buf.WriteString(" OFFSET ?")
+		if *q.OffsetValue < 0 {
+			*q.OffsetValue = -*q.OffsetValue
+		}
+		*args = append(*args, *q.OffsetValue)
+	}
+	if bracket {
+		buf.WriteString(")")
+	}
+	if !q.Nested {
+		if q.Log != nil {
+			query := buf.String()
+			var logOutput string
+			switch {
+			case Lstats&q.LogFlag != 0:
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(*args) +
+					"\n----[ with bind values ]----\n" + QuestionInterpolate(query, *args...)
+			case Linterpolate&q.LogFlag != 0:
+				logOutput = QuestionInterpolate(query, *args...)
+			default:
+				logOutput = query + " " + fmt.Sprint(*args)
+			}
+			switch q.Log.(type) {
+			case *log.Logger:
+				q.Log.Output(q.LogSkip+2, logOutput)
+			default:
+				q.Log.Output(q.LogSkip+1, logOutput)
+			}
+		}
+	}
+}
+
+// checkColumnCounts checks that the select lists of the queries have the same
+// number of columns. Queries whose columns cannot be determined (e.g.
+// CustomQueries or SELECT *) are skipped.
+func (q VariadicQuery) checkColumnCounts() error {
+	want, first := -1, 0
+	for i, query := range q.Queries {
+		count, ok := columnCount(query)
+		if !ok {
+			continue
+		}
+		if want < 0 {
+			want, first = count, i
+			continue
+		}
+		if count != want {
+			return fmt.Errorf("%s query %d has %d columns, but query %d has %d columns", q.Operator, i+1, count, first+1, want)
+		}
+	}
+	return nil
+}
+
+// tableQualifiers returns the names (or aliases) of the FROM and JOIN tables
+// of the queries.
+func (q VariadicQuery) tableQualifiers() []string {
+	var qualifiers []string
+	appendQualifier := func(table Table) {
+		if table == nil {
+			return
+		}
+		if alias := table.GetAlias(); alias != "" {
+			qualifiers = append(qualifiers, alias)
+		} else {
+			qualifiers = append(qualifiers, table.GetName())
+		}
+	}
+	for _, query := range q.Queries {
+		switch v := query.(type) {
+		case SelectQuery:
+			appendQualifier(v.FromTable)
+			for _, join := range v.JoinTables {
+				appendQualifier(join.Table)
+			}
+		case VariadicQuery:
+			qualifiers = append(qualifiers, v.tableQualifiers()...)
+		}
+	}
+	return qualifiers
+}
+
+// columnCount returns the number of columns produced by the query. It reports
+// false if the number of columns cannot be determined.
+func columnCount(query Query) (int, bool) {
+	fields, ok := queryFields(query)
+	if !ok {
+		return 0, false
+	}
+	for _, field := range fields {
+		if field != nil && strings.HasSuffix(field.GetName(), "*") {
+			return 0, false
+		}
+	}
+	return len(fields), true
+}
+
+// OrderBy sets the order by fields of the combined result of the
+// VariadicQuery. The fields must be output columns e.g. obtained from the
+// NumberField or StringField methods of the VariadicQuery.
+func (q VariadicQuery) OrderBy(fields ...Field) VariadicQuery {
+	q.OrderByFields = append(q.OrderByFields, fields...)
+	return q
+}
+
+// Limit sets the limit of the combined result of the VariadicQuery.
+func (q VariadicQuery) Limit(limit int) VariadicQuery {
+	num := int64(limit)
+	q.LimitValue = &num
+	return q
+}
+
+// Offset sets the offset of the combined result of the VariadicQuery.
+func (q VariadicQuery) Offset(offset int) VariadicQuery {
+	num := int64(offset)
+	q.OffsetValue = &num
+	return q
+}
+
+// Selectx sets the mapper function and accumulator function in the
+// VariadicQuery. The fields in the mapper are scanned positionally, so they
+// must be in the same order as the select lists of the queries.
+func (q VariadicQuery) Selectx(mapper func(*Row), accumulator func()) VariadicQuery {
+	q.Mapper = mapper
+	q.Accumulator = accumulator
+	return q
+}
+
+// SelectRowx sets the mapper function in the VariadicQuery.
+func (q VariadicQuery) SelectRowx(mapper func(*Row)) VariadicQuery {
+	q.Mapper = mapper
+	return q
+}
+
+// Fetch will run VariadicQuery with the given DB. It then maps the results
+// based on the mapper function (and optionally runs the accumulator function).
+func (q VariadicQuery) Fetch(db DB) (err error) {
+	q.LogSkip += 1
+	return q.FetchContext(nil, db)
+}
+
+// FetchContext will run VariadicQuery with the given DB and context. It then
+// maps the results based on the mapper function (and optionally runs the
+// accumulator function).
+func (q VariadicQuery) FetchContext(ctx context.Context, db DB) (err error) {
+	if db == nil {
+		if q.DB == nil {
+			return errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	if q.Mapper == nil {
+		return fmt.Errorf("Cannot call Fetch without a mapper")
+	}
+	if err = q.checkColumnCounts(); err != nil {
+		return err
+	}
+	r := &Row{}
+	buf := &strings.Builder{}
+	var args []interface{}
+	logBuf := &strings.Builder{}
+	start := time.Now()
+	var rowcount int
+	defer func() {
+		if p := recover(); p != nil {
+			switch v := p.(type) {
+			case ExitCode:
+				if v != ExitPeacefully {
+					err = v
+				}
+				return
+			case error:
+				err = v
+			default:
+				err = fmt.Errorf("%#v", p)
+			}
+			// a panic in the first call of the mapper happens before the
+			// query is built, so there is no query to wrap the error with
+			if buf.Len() > 0 {
+				err = newQueryError(string(q.Operator), buf.String(), args, r.fields, err)
 			}
+			return
 		}
-		if q.Nested {
-			buf.WriteString(")")
+		if q.Log == nil {
+			return
 		}
+		elapsed := time.Since(start)
+		if Lresults&q.LogFlag != 0 && rowcount > 5 {
+			logBuf.WriteString("\n...")
+		}
+		if Lstats&q.LogFlag != 0 {
+			logBuf.WriteString("\n(Fetched ")
+			logBuf.WriteString(strconv.Itoa(rowcount))
+			logBuf.WriteString(" rows in ")
+			logBuf.WriteString(elapsed.String())
+			logBuf.WriteString(")")
+		}
+		if logBuf.Len() > 0 {
+			switch q.Log.(type) {
+			case *log.Logger:
+				q.Log.Output(q.LogSkip+2, logBuf.String())
+			default:
+				q.Log.Output(q.LogSkip+1, logBuf.String())
+			}
+		}
+	}()
+	q.Mapper(r)
+	for _, query := range q.Queries {
+		if count, ok := columnCount(query); ok && count != len(r.fields) {
+			return fmt.Errorf("the mapper function has %d fields, but the %s queries have %d columns", len(r.fields), q.Operator, count)
+		}
+	}
+	q.LogSkip += 1
+	q.AppendSQL(buf, &args)
+	unescapeQuestionMarks(buf)
+	if err = ArgsError(args); err != nil {
+		return newQueryError(string(q.Operator), buf.String(), args, nil, err)
+	}
+	if ctx == nil {
+		r.rows, err = db.Query(buf.String(), args...)
+	} else {
+		r.rows, err = db.QueryContext(ctx, buf.String(), args...)
+	}
+	if err != nil {
+		return newQueryError(string(q.Operator), buf.String(), args, nil, err)
+	}
+	tmpbuf := &strings.Builder{}
+	var tmpargs []interface{}
+	defer r.rows.Close()
+	if len(r.dest) == 0 {
+		return nil
+	}
+	for r.rows.Next() {
+		rowcount++
+		err = r.scan()
+		if err != nil {
+			return newQueryError(string(q.Operator), buf.String(), args, r.fields, err)
+		}
+		if q.Log != nil && Lresults&q.LogFlag != 0 && rowcount <= 5 {
+			logBuf.WriteString("\n----[ Row ")
+			logBuf.WriteString(strconv.Itoa(rowcount))
+			logBuf.WriteString(" ]----")
+			for i := range r.dest {
+				tmpbuf.Reset()
+				tmpargs = tmpargs[:0]
+				r.fields[i].AppendSQLExclude(tmpbuf, &tmpargs, nil)
+				logBuf.WriteString("\n")
+				logBuf.WriteString(QuestionInterpolate(tmpbuf.String(), tmpargs...))
+				logBuf.WriteString(": ")
+				AppendSQLDisplay(logBuf, r.dest[i])
+			}
+		}
+		r.index = 0
+		q.Mapper(r)
+		if q.Accumulator == nil {
+			break
+		}
+		q.Accumulator()
 	}
+	if rowcount == 0 && q.Accumulator == nil {
+		return sql.ErrNoRows
+	}
+	if e := r.rows.Close(); e != nil {
+		return newQueryError(string(q.Operator), buf.String(), args, nil, e)
+	}
+	return newQueryError(string(q.Operator), buf.String(), args, nil, r.rows.Err())
 }
 
 // As aliases the VariadicQuery i.e. 'query AS alias'.
@@ -78,10 +422,45 @@ func (q VariadicQuery) As(alias string) VariadicQuery {
 // Get returns a Field from the VariadicQuery, identified by fieldName.
 func (q VariadicQuery) Get(fieldName string) CustomField {
 	return CustomField{
-		Format: q.Alias + "." + fieldName,
+		Format: qualify(derivedAlias(q), fieldName),
 	}
 }
 
+// NumberField returns the NumberField column identified by name of the
+// VariadicQuery when used as a derived table. The column must appear in the
+// select list of the first query.
+func (q VariadicQuery) NumberField(name string) NumberField {
+	return derivedNumberField(q, q, nil, name)
+}
+
+// StringField returns the StringField column identified by name of the
+// VariadicQuery when used as a derived table. The column must appear in the
+// select list of the first query.
+func (q VariadicQuery) StringField(name string) StringField {
+	return derivedStringField(q, q, nil, name)
+}
+
+// TimeField returns the TimeField column identified by name of the
+// VariadicQuery when used as a derived table. The column must appear in the
+// select list of the first query.
+func (q VariadicQuery) TimeField(name string) TimeField {
+	return derivedTimeField(q, q, nil, name)
+}
+
+// BooleanField returns the BooleanField column identified by name of the
+// VariadicQuery when used as a derived table. The column must appear in the
+// select list of the first query.
+func (q VariadicQuery) BooleanField(name string) BooleanField {
+	return derivedBooleanField(q, q, nil, name)
+}
+
+// JSONField returns the JSONField column identified by name of the
+// VariadicQuery when used as a derived table. The column must appear in the
+// select list of the first query.
+func (q VariadicQuery) JSONField(name string) JSONField {
+	return derivedJSONField(q, q, nil, name)
+}
+
 // GetAlias returns the alias of the VariadicQuery.
 func (q VariadicQuery) GetAlias() string {
 	return q.Alias
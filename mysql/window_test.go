@@ -46,11 +46,18 @@ func TestWindow_AppendSQL(t *testing.T) {
 			"my_window",
 			nil,
 		},
-		func() TT {
-			desc := "randomly generated name"
-			w := Window{}.Name()
-			return TT{desc, w, w.WindowName, nil}
-		}(),
+		{
+			"generated name",
+			Window{}.Name(),
+			"w_bc1x22",
+			nil,
+		},
+		{
+			"generated name is derived from the window definition",
+			PartitionBy(ur.USER_ID).Name(),
+			"w_ig38r7",
+			nil,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
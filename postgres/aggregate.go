@@ -0,0 +1,277 @@
+package sq
+
+import "strings"
+
+// Aggregate represents an aggregate function call i.e.
+// 'NAME([DISTINCT] args [ORDER BY fields]) [WITHIN GROUP (ORDER BY fields)]
+// [FILTER (WHERE predicates)]'. The aggregate functions in this package wrap an
+// Aggregate in a typed field like NumberAggregate or StringAggregate, so you
+// usually only need to use an Aggregate directly for aggregates that do not
+// have a typed result.
+type Aggregate struct {
+	Alias             string
+	Name              string
+	Args              []interface{}
+	IsDistinct        bool
+	OrderByFields     Fields
+	WithinGroupFields Fields
+	FilterPredicates  []Predicate
+}
+
+// AppendSQLExclude marshals the Aggregate into a buffer and an args slice. It
+// propagates the excludedTableQualifiers down to its child elements.
+func (a Aggregate) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
+	buf.WriteString(a.Name)
+	buf.WriteString("(")
+	if a.IsDistinct {
+		buf.WriteString("DISTINCT ")
+	}
+	if len(a.Args) > 0 {
+		format := "?" + strings.Repeat(", ?", len(a.Args)-1)
+		ExpandValues(buf, args, excludedTableQualifiers, format, a.Args)
+	}
+	if len(a.OrderByFields) > 0 {
+		buf.WriteString(" ORDER BY ")
+		a.OrderByFields.AppendSQLExclude(buf, args, excludedTableQualifiers)
+	}
+	buf.WriteString(")")
+	if len(a.WithinGroupFields) > 0 {
+		buf.WriteString(" WITHIN GROUP (ORDER BY ")
+		a.WithinGroupFields.AppendSQLExclude(buf, args, excludedTableQualifiers)
+		buf.WriteString(")")
+	}
+	if len(a.FilterPredicates) > 0 {
+		buf.WriteString(" FILTER (WHERE ")
+		VariadicPredicate{
+			Toplevel:   true,
+			Predicates: a.FilterPredicates,
+		}.AppendSQLExclude(buf, args, excludedTableQualifiers)
+		buf.WriteString(")")
+	}
+}
+
+// Distinct returns a new Aggregate that only aggregates distinct values i.e.
+// 'NAME(DISTINCT args)'.
+func (a Aggregate) Distinct() Aggregate {
+	a.IsDistinct = true
+	return a
+}
+
+// OrderBy returns a new Aggregate that aggregates its input rows in the given
+// order i.e. 'NAME(args ORDER BY fields)'.
+func (a Aggregate) OrderBy(fields ...Field) Aggregate {
+	a.OrderByFields = fields
+	return a
+}
+
+// Filter returns a new Aggregate that only aggregates the input rows that
+// satisfy the predicates i.e. 'NAME(args) FILTER (WHERE predicates)'. The
+// predicates are AND-ed together.
+func (a Aggregate) Filter(predicates ...Predicate) Aggregate {
+	a.FilterPredicates = predicates
+	return a
+}
+
+// As returns a new Aggregate with the new field Alias i.e. 'field AS Alias'.
+func (a Aggregate) As(alias string) Aggregate {
+	a.Alias = alias
+	return a
+}
+
+// GetAlias implements the Field interface. It returns the Alias of the
+// Aggregate.
+func (a Aggregate) GetAlias() string {
+	return a.Alias
+}
+
+// GetName implements the Field interface. It returns the Name of the
+// Aggregate.
+func (a Aggregate) GetName() string {
+	return a.Name
+}
+
+// NumberAggregate is an aggregate function that returns a number. It can be
+// used anywhere a NumberField can.
+type NumberAggregate struct {
+	NumberField
+	aggregate Aggregate
+}
+
+func numberAggregate(aggregate Aggregate) NumberAggregate {
+	return NumberAggregate{
+		NumberField: numberFunction("?", aggregate).As(aggregate.Alias),
+		aggregate:   aggregate,
+	}
+}
+
+// As returns a new NumberAggregate with the new field Alias i.e. 'field AS
+// Alias'.
+func (a NumberAggregate) As(alias string) NumberAggregate {
+	return numberAggregate(a.aggregate.As(alias))
+}
+
+// Distinct returns a new NumberAggregate that only aggregates distinct values
+// i.e. 'NAME(DISTINCT args)'.
+func (a NumberAggregate) Distinct() NumberAggregate {
+	return numberAggregate(a.aggregate.Distinct())
+}
+
+// Filter returns a new NumberAggregate that only aggregates the input rows
+// that satisfy the predicates i.e. 'NAME(args) FILTER (WHERE predicates)'.
+func (a NumberAggregate) Filter(predicates ...Predicate) NumberAggregate {
+	return numberAggregate(a.aggregate.Filter(predicates...))
+}
+
+// StringAggregate is an aggregate function that returns a string. It can be
+// used anywhere a StringField can.
+type StringAggregate struct {
+	StringField
+	aggregate Aggregate
+}
+
+func stringAggregate(aggregate Aggregate) StringAggregate {
+	return StringAggregate{
+		StringField: stringFunction("?", aggregate).As(aggregate.Alias),
+		aggregate:   aggregate,
+	}
+}
+
+// As returns a new StringAggregate with the new field Alias i.e. 'field AS
+// Alias'.
+func (a StringAggregate) As(alias string) StringAggregate {
+	return stringAggregate(a.aggregate.As(alias))
+}
+
+// Distinct returns a new StringAggregate that only aggregates distinct values
+// i.e. 'NAME(DISTINCT args)'.
+func (a StringAggregate) Distinct() StringAggregate {
+	return stringAggregate(a.aggregate.Distinct())
+}
+
+// OrderBy returns a new StringAggregate that concatenates its input rows in
+// the given order i.e. 'NAME(args ORDER BY fields)'.
+func (a StringAggregate) OrderBy(fields ...Field) StringAggregate {
+	return stringAggregate(a.aggregate.OrderBy(fields...))
+}
+
+// Filter returns a new StringAggregate that only aggregates the input rows
+// that satisfy the predicates i.e. 'NAME(args) FILTER (WHERE predicates)'.
+func (a StringAggregate) Filter(predicates ...Predicate) StringAggregate {
+	return stringAggregate(a.aggregate.Filter(predicates...))
+}
+
+// ArrayAggregate is an aggregate function that returns an array. It can be
+// used anywhere an ArrayField can.
+type ArrayAggregate struct {
+	ArrayField
+	aggregate Aggregate
+}
+
+func arrayAggregate(aggregate Aggregate) ArrayAggregate {
+	format := "?"
+	return ArrayAggregate{
+		ArrayField: ArrayField{
+			alias:  aggregate.Alias,
+			format: &format,
+			values: []interface{}{aggregate},
+		},
+		aggregate: aggregate,
+	}
+}
+
+// As returns a new ArrayAggregate with the new field Alias i.e. 'field AS
+// Alias'.
+func (a ArrayAggregate) As(alias string) ArrayAggregate {
+	return arrayAggregate(a.aggregate.As(alias))
+}
+
+// Distinct returns a new ArrayAggregate that only aggregates distinct values
+// i.e. 'NAME(DISTINCT args)'.
+func (a ArrayAggregate) Distinct() ArrayAggregate {
+	return arrayAggregate(a.aggregate.Distinct())
+}
+
+// OrderBy returns a new ArrayAggregate that collects its input rows in the
+// given order i.e. 'NAME(args ORDER BY fields)'.
+func (a ArrayAggregate) OrderBy(fields ...Field) ArrayAggregate {
+	return arrayAggregate(a.aggregate.OrderBy(fields...))
+}
+
+// Filter returns a new ArrayAggregate that only aggregates the input rows
+// that satisfy the predicates i.e. 'NAME(args) FILTER (WHERE predicates)'.
+func (a ArrayAggregate) Filter(predicates ...Predicate) ArrayAggregate {
+	return arrayAggregate(a.aggregate.Filter(predicates...))
+}
+
+// JSONAggregate is an aggregate function that returns JSON. It can be used
+// anywhere a JSONField can.
+type JSONAggregate struct {
+	JSONField
+	aggregate Aggregate
+}
+
+func jsonAggregate(aggregate Aggregate) JSONAggregate {
+	format := "?"
+	return JSONAggregate{
+		JSONField: JSONField{
+			alias:  aggregate.Alias,
+			format: &format,
+			values: []interface{}{aggregate},
+		},
+		aggregate: aggregate,
+	}
+}
+
+// As returns a new JSONAggregate with the new field Alias i.e. 'field AS
+// Alias'.
+func (a JSONAggregate) As(alias string) JSONAggregate {
+	return jsonAggregate(a.aggregate.As(alias))
+}
+
+// Distinct returns a new JSONAggregate that only aggregates distinct values
+// i.e. 'NAME(DISTINCT args)'.
+func (a JSONAggregate) Distinct() JSONAggregate {
+	return jsonAggregate(a.aggregate.Distinct())
+}
+
+// OrderBy returns a new JSONAggregate that collects its input rows in the
+// given order i.e. 'NAME(args ORDER BY fields)'.
+func (a JSONAggregate) OrderBy(fields ...Field) JSONAggregate {
+	return jsonAggregate(a.aggregate.OrderBy(fields...))
+}
+
+// Filter returns a new JSONAggregate that only aggregates the input rows that
+// satisfy the predicates i.e. 'NAME(args) FILTER (WHERE predicates)'.
+func (a JSONAggregate) Filter(predicates ...Predicate) JSONAggregate {
+	return jsonAggregate(a.aggregate.Filter(predicates...))
+}
+
+// BooleanAggregate is an aggregate function that returns a boolean. It can be
+// used anywhere a Predicate can, e.g. in the HAVING clause.
+type BooleanAggregate struct {
+	CustomPredicate
+	aggregate Aggregate
+}
+
+func booleanAggregate(aggregate Aggregate) BooleanAggregate {
+	return BooleanAggregate{
+		CustomPredicate: CustomPredicate{
+			Alias:  aggregate.Alias,
+			Format: "?",
+			Values: []interface{}{aggregate},
+		},
+		aggregate: aggregate,
+	}
+}
+
+// As returns a new BooleanAggregate with the new field Alias i.e. 'field AS
+// Alias'.
+func (a BooleanAggregate) As(alias string) BooleanAggregate {
+	return booleanAggregate(a.aggregate.As(alias))
+}
+
+// Filter returns a new BooleanAggregate that only aggregates the input rows
+// that satisfy the predicates i.e. 'NAME(args) FILTER (WHERE predicates)'.
+func (a BooleanAggregate) Filter(predicates ...Predicate) BooleanAggregate {
+	return booleanAggregate(a.aggregate.Filter(predicates...))
+}
@@ -8,6 +8,24 @@ func Count() NumberField {
 	}
 }
 
+// CountAgg represents the COUNT(*) aggregate function as a NumberAggregate,
+// which can be filtered.
+func CountAgg() NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "COUNT",
+		Args: []interface{}{FieldLiteral("*")},
+	})
+}
+
+// CountDistinct represents the COUNT(DISTINCT field) aggregate function.
+func CountDistinct(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name:       "COUNT",
+		Args:       []interface{}{field},
+		IsDistinct: true,
+	})
+}
+
 // CountOver represents the COUNT(*) OVER window function.
 func CountOver(window Window) NumberField {
 	format := "COUNT(*) OVER ?"
@@ -26,6 +44,15 @@ func Sum(field interface{}) NumberField {
 	}
 }
 
+// SumAgg represents the SUM() aggregate function as a
+// NumberAggregate, which supports DISTINCT and FILTER.
+func SumAgg(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "SUM",
+		Args: []interface{}{field},
+	})
+}
+
 // SumOver represents the SUM() OVER window function.
 func SumOver(field interface{}, window Window) NumberField {
 	format := "SUM(?) OVER ?"
@@ -44,6 +71,15 @@ func Avg(field interface{}) NumberField {
 	}
 }
 
+// AvgAgg represents the AVG() aggregate function as a
+// NumberAggregate, which supports DISTINCT and FILTER.
+func AvgAgg(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "AVG",
+		Args: []interface{}{field},
+	})
+}
+
 // AvgOver represents the AVG() OVER window function.
 func AvgOver(field interface{}, window Window) NumberField {
 	format := "AVG(?) OVER ?"
@@ -62,6 +98,15 @@ func Min(field interface{}) NumberField {
 	}
 }
 
+// MinAgg represents the MIN() aggregate function as a
+// NumberAggregate, which supports DISTINCT and FILTER.
+func MinAgg(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "MIN",
+		Args: []interface{}{field},
+	})
+}
+
 // MinOver represents the MIN() OVER window function.
 func MinOver(field interface{}, window Window) NumberField {
 	format := "MIN(?) OVER ?"
@@ -80,6 +125,15 @@ func Max(field interface{}) NumberField {
 	}
 }
 
+// MaxAgg represents the MAX() aggregate function as a
+// NumberAggregate, which supports DISTINCT and FILTER.
+func MaxAgg(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "MAX",
+		Args: []interface{}{field},
+	})
+}
+
 // MaxOver represents the MAX() OVER window function.
 func MaxOver(field interface{}, window Window) NumberField {
 	format := "MAX(?) OVER ?"
@@ -88,3 +142,146 @@ func MaxOver(field interface{}, window Window) NumberField {
 		values: []interface{}{field, window},
 	}
 }
+
+// StringAgg represents the STRING_AGG(field, separator) aggregate function,
+// which concatenates the input values into a string. Use OrderBy to control
+// the order of concatenation.
+func StringAgg(field interface{}, separator string) StringAggregate {
+	return stringAggregate(Aggregate{
+		Name: "STRING_AGG",
+		Args: []interface{}{field, separator},
+	})
+}
+
+// ArrayAgg represents the ARRAY_AGG() aggregate function, which collects the
+// input values into an array.
+func ArrayAgg(field interface{}) ArrayAggregate {
+	return arrayAggregate(Aggregate{
+		Name: "ARRAY_AGG",
+		Args: []interface{}{field},
+	})
+}
+
+// JSONAgg represents the JSON_AGG() aggregate function, which collects the
+// input values into a JSON array.
+func JSONAgg(field interface{}) JSONAggregate {
+	return jsonAggregate(Aggregate{
+		Name: "JSON_AGG",
+		Args: []interface{}{field},
+	})
+}
+
+// JSONBAgg represents the JSONB_AGG() aggregate function, which collects the
+// input values into a JSONB array.
+func JSONBAgg(field interface{}) JSONAggregate {
+	return jsonAggregate(Aggregate{
+		Name: "JSONB_AGG",
+		Args: []interface{}{field},
+	})
+}
+
+// JSONObjectAgg represents the JSON_OBJECT_AGG(key, value) aggregate
+// function, which collects the key/value pairs into a JSON object.
+func JSONObjectAgg(key, value interface{}) JSONAggregate {
+	return jsonAggregate(Aggregate{
+		Name: "JSON_OBJECT_AGG",
+		Args: []interface{}{key, value},
+	})
+}
+
+// JSONBObjectAgg represents the JSONB_OBJECT_AGG(key, value) aggregate
+// function, which collects the key/value pairs into a JSONB object.
+func JSONBObjectAgg(key, value interface{}) JSONAggregate {
+	return jsonAggregate(Aggregate{
+		Name: "JSONB_OBJECT_AGG",
+		Args: []interface{}{key, value},
+	})
+}
+
+// BoolAnd represents the BOOL_AND() aggregate function, which is true if all
+// input values are true.
+func BoolAnd(field interface{}) BooleanAggregate {
+	return booleanAggregate(Aggregate{
+		Name: "BOOL_AND",
+		Args: []interface{}{field},
+	})
+}
+
+// BoolOr represents the BOOL_OR() aggregate function, which is true if any
+// input value is true.
+func BoolOr(field interface{}) BooleanAggregate {
+	return booleanAggregate(Aggregate{
+		Name: "BOOL_OR",
+		Args: []interface{}{field},
+	})
+}
+
+// StddevSamp represents the STDDEV_SAMP() aggregate function, which returns
+// the sample standard deviation of the input values.
+func StddevSamp(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "STDDEV_SAMP",
+		Args: []interface{}{field},
+	})
+}
+
+// StddevPop represents the STDDEV_POP() aggregate function, which returns the
+// population standard deviation of the input values.
+func StddevPop(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "STDDEV_POP",
+		Args: []interface{}{field},
+	})
+}
+
+// VarSamp represents the VAR_SAMP() aggregate function, which returns the
+// sample variance of the input values.
+func VarSamp(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "VAR_SAMP",
+		Args: []interface{}{field},
+	})
+}
+
+// VarPop represents the VAR_POP() aggregate function, which returns the
+// population variance of the input values.
+func VarPop(field interface{}) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name: "VAR_POP",
+		Args: []interface{}{field},
+	})
+}
+
+// PercentileCont represents the PERCENTILE_CONT(fraction) WITHIN GROUP (ORDER
+// BY field) ordered-set aggregate function, which returns the value at the
+// fraction (between 0 and 1), interpolating between adjacent input values if
+// needed.
+func PercentileCont(fraction float64, field Field) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name:              "PERCENTILE_CONT",
+		Args:              []interface{}{fraction},
+		WithinGroupFields: Fields{field},
+	})
+}
+
+// PercentileDisc represents the PERCENTILE_DISC(fraction) WITHIN GROUP (ORDER
+// BY field) ordered-set aggregate function, which returns the first input
+// value whose position in the ordering equals or exceeds the fraction (between
+// 0 and 1).
+func PercentileDisc(fraction float64, field Field) NumberAggregate {
+	return numberAggregate(Aggregate{
+		Name:              "PERCENTILE_DISC",
+		Args:              []interface{}{fraction},
+		WithinGroupFields: Fields{field},
+	})
+}
+
+// Mode represents the MODE() WITHIN GROUP (ORDER BY field) ordered-set
+// aggregate function, which returns the most frequent input value. The result
+// has the same type as the field, so it is returned as an untyped Aggregate.
+func Mode(field Field) Aggregate {
+	return Aggregate{
+		Name:              "MODE",
+		WithinGroupFields: Fields{field},
+	}
+}
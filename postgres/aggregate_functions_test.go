@@ -101,3 +101,62 @@ func TestAggregateFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateFunctions_Extended(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := USERS().As("u")
+	tests := []TT{
+		{"CountDistinct", CountDistinct(u.EMAIL), "COUNT(DISTINCT u.email)", nil},
+		{"Sum Distinct", SumAgg(u.USER_ID).Distinct(), "SUM(DISTINCT u.user_id)", nil},
+		{
+			"Count Filter",
+			CountAgg().Filter(u.EMAIL.LikeString("%@gmail.com"), u.USER_ID.GtInt(10)),
+			"COUNT(*) FILTER (WHERE u.email LIKE ? AND u.user_id > ?)",
+			[]interface{}{"%@gmail.com", 10},
+		},
+		{"Count arithmetic", Count().AddInt(1), "COUNT(*) + ?", []interface{}{1}},
+		{
+			"StringAgg",
+			StringAgg(u.DISPLAYNAME, ", ").Distinct().OrderBy(u.DISPLAYNAME.Desc()),
+			"STRING_AGG(DISTINCT u.displayname, ? ORDER BY u.displayname DESC)",
+			[]interface{}{", "},
+		},
+		{"ArrayAgg", ArrayAgg(u.USER_ID).OrderBy(u.EMAIL), "ARRAY_AGG(u.user_id ORDER BY u.email)", nil},
+		{"ArrayAgg Contains", ArrayAgg(u.USER_ID).Contains(Array([]int{1})), "ARRAY_AGG(u.user_id) @> ARRAY[?]", []interface{}{1}},
+		{"JSONAgg", JSONAgg(u.EMAIL), "JSON_AGG(u.email)", nil},
+		{"JSONBAgg", JSONBAgg(u.EMAIL).Filter(u.EMAIL.IsNotNull()), "JSONB_AGG(u.email) FILTER (WHERE u.email IS NOT NULL)", nil},
+		{"JSONObjectAgg", JSONObjectAgg(u.EMAIL, u.USER_ID), "JSON_OBJECT_AGG(u.email, u.user_id)", nil},
+		{"JSONBObjectAgg", JSONBObjectAgg(u.EMAIL, u.USER_ID), "JSONB_OBJECT_AGG(u.email, u.user_id)", nil},
+		{"BoolAnd", BoolAnd(u.USER_ID.GtInt(0)), "BOOL_AND(u.user_id > ?)", []interface{}{0}},
+		{"BoolOr Not", BoolOr(u.EMAIL.IsNull()).Not(), "NOT BOOL_OR(u.email IS NULL)", nil},
+		{"StddevSamp", StddevSamp(u.USER_ID), "STDDEV_SAMP(u.user_id)", nil},
+		{"StddevPop", StddevPop(u.USER_ID), "STDDEV_POP(u.user_id)", nil},
+		{"VarSamp", VarSamp(u.USER_ID), "VAR_SAMP(u.user_id)", nil},
+		{"VarPop", VarPop(u.USER_ID), "VAR_POP(u.user_id)", nil},
+		{
+			"PercentileCont",
+			PercentileCont(0.5, u.USER_ID).Filter(u.EMAIL.IsNotNull()),
+			"PERCENTILE_CONT(?) WITHIN GROUP (ORDER BY u.user_id) FILTER (WHERE u.email IS NOT NULL)",
+			[]interface{}{0.5},
+		},
+		{"PercentileDisc", PercentileDisc(0.9, u.USER_ID.Desc()), "PERCENTILE_DISC(?) WITHIN GROUP (ORDER BY u.user_id DESC)", []interface{}{0.9}},
+		{"Mode", Mode(u.EMAIL), "MODE() WITHIN GROUP (ORDER BY u.email)", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
@@ -5,11 +5,21 @@ import (
 	"strings"
 )
 
-// ArrayField either represents an ARRAY column, or a literal slice value.
+// ArrayField either represents an ARRAY column, an array expression or a
+// literal slice value.
 type ArrayField struct {
 	// ArrayField will be one of the following:
 
-	// 1) Literal slice value (only []bool, []float64, []int64 or []string
+	// 1) Array expression
+	// Examples of array expressions:
+	// | query                          | args  |
+	// |--------------------------------|-------|
+	// | REGEXP_MATCH(users.email, ?)   | @(.*) |
+	// | ARRAY_APPEND(film.features, ?) | x     |
+	format *string
+	values []interface{}
+
+	// 2) Literal slice value (only []bool, []float64, []int64 or []string
 	// slices are supported.) Nested slices are also not supported even though
 	// both Go and Postgres support nested slices/arrays because I'm not even
 	// sure if it's possible to convert between the two with lib/pq.
@@ -24,8 +34,8 @@ type ArrayField struct {
 	// | ARRAY[?, ?, ?]    | apple, banana, cucumber |
 	value interface{}
 
-	// 2) Array column
-	// Examples of boolean columns:
+	// 3) Array column
+	// Examples of array columns:
 	// | query                 | args |
 	// |-----------------------|------|
 	// | film.special_features |      |
@@ -42,8 +52,11 @@ type ArrayField struct {
 // excludedTableQualifiers list.
 func (f ArrayField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
 	switch {
+	case f.format != nil:
+		// 1) Array expression
+		ExpandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
 	case f.value != nil:
-		// 1) Literal slice value
+		// 2) Literal slice value
 		switch array := f.value.(type) {
 		case []bool:
 			if len(array) == 0 {
@@ -104,34 +117,8 @@ func (f ArrayField) AppendSQLExclude(buf *strings.Builder, args *[]interface{},
 			buf.WriteString(fmt.Sprintf("(unsupported type %#v: only []bool/[]float64/[]int64/[]string/[]int slices are supported.)", f.value))
 		}
 	default:
-		// 2) Array column
-		tableQualifier := f.table.GetAlias()
-		if tableQualifier == "" {
-			tableQualifier = f.table.GetName()
-		}
-		for i := range excludedTableQualifiers {
-			if tableQualifier == excludedTableQualifiers[i] {
-				tableQualifier = ""
-				break
-			}
-		}
-		if tableQualifier != "" {
-			if strings.ContainsAny(tableQualifier, " \t") {
-				buf.WriteString(`"`)
-				buf.WriteString(tableQualifier)
-				buf.WriteString(`".`)
-			} else {
-				buf.WriteString(tableQualifier)
-				buf.WriteString(".")
-			}
-		}
-		if strings.ContainsAny(f.name, " \t") {
-			buf.WriteString(`"`)
-			buf.WriteString(f.name)
-			buf.WriteString(`"`)
-		} else {
-			buf.WriteString(f.name)
-		}
+		// 3) Array column
+		appendColumn(buf, f.table, f.name, excludedTableQualifiers)
 	}
 	if f.descending != nil {
 		if *f.descending {
@@ -310,6 +297,126 @@ func (f ArrayField) Concat(field ArrayField) Field {
 	}
 }
 
+// subscriptable returns the ArrayField in a form that can be subscripted i.e.
+// 'X[1]'. Array expressions and literal slices need to be bracketed first,
+// otherwise Postgres would parse the subscript as part of the expression.
+func (f ArrayField) subscriptable() interface{} {
+	if f.format == nil && f.value == nil {
+		return f
+	}
+	format := "(?)"
+	return ArrayField{
+		format: &format,
+		values: []interface{}{f},
+	}
+}
+
+// At returns the array element at the given index i.e. 'X[index]'. Postgres
+// arrays are 1-indexed. The element is returned as a CustomField because the
+// ArrayField does not know its element type; use the At method on
+// StringArrayField or NumberArrayField to get a typed element instead.
+func (f ArrayField) At(index int) CustomField {
+	return CustomField{
+		Format: "?[?]",
+		Values: []interface{}{f.subscriptable(), index},
+	}
+}
+
+// Slice returns the array slice from the lower index to the upper index
+// (inclusive) i.e. 'X[lower:upper]'.
+func (f ArrayField) Slice(lower, upper int) ArrayField {
+	format := "?[?:?]"
+	return ArrayField{
+		format: &format,
+		values: []interface{}{f.subscriptable(), lower, upper},
+	}
+}
+
+// Length represents the ARRAY_LENGTH(X, 1) function, which returns the length
+// of the first dimension of the array. It returns NULL for empty arrays.
+func (f ArrayField) Length() NumberField {
+	return numberFunction("ARRAY_LENGTH(?, 1)", f)
+}
+
+// Cardinality represents the CARDINALITY(X) function, which returns the total
+// number of elements in the array. It returns 0 for empty arrays.
+func (f ArrayField) Cardinality() NumberField {
+	return numberFunction("CARDINALITY(?)", f)
+}
+
+// Append represents the ARRAY_APPEND(X, value) function, which returns the
+// array with the value appended to the end.
+func (f ArrayField) Append(value interface{}) ArrayField {
+	format := "ARRAY_APPEND(?, ?)"
+	return ArrayField{
+		format: &format,
+		values: []interface{}{f, value},
+	}
+}
+
+// Prepend represents the ARRAY_PREPEND(value, X) function, which returns the
+// array with the value prepended to the start.
+func (f ArrayField) Prepend(value interface{}) ArrayField {
+	format := "ARRAY_PREPEND(?, ?)"
+	return ArrayField{
+		format: &format,
+		values: []interface{}{value, f},
+	}
+}
+
+// Remove represents the ARRAY_REMOVE(X, value) function, which returns the
+// array with every element equal to the value removed.
+func (f ArrayField) Remove(value interface{}) ArrayField {
+	format := "ARRAY_REMOVE(?, ?)"
+	return ArrayField{
+		format: &format,
+		values: []interface{}{f, value},
+	}
+}
+
+// Position represents the ARRAY_POSITION(X, value) function, which returns
+// the index of the first occurrence of the value in the array, or NULL if it
+// is not found.
+func (f ArrayField) Position(value interface{}) NumberField {
+	return numberFunction("ARRAY_POSITION(?, ?)", f, value)
+}
+
+// SetAppend returns a FieldAssignment that appends the value to the ArrayField
+// i.e. 'field = ARRAY_APPEND(field, value)'.
+func (f ArrayField) SetAppend(value interface{}) FieldAssignment {
+	return FieldAssignment{
+		Field: f,
+		Value: f.Append(value),
+	}
+}
+
+// SetRemove returns a FieldAssignment that removes the value from the
+// ArrayField i.e. 'field = ARRAY_REMOVE(field, value)'.
+func (f ArrayField) SetRemove(value interface{}) FieldAssignment {
+	return FieldAssignment{
+		Field: f,
+		Value: f.Remove(value),
+	}
+}
+
+// InQuery returns an 'X IN (subquery)' Predicate. The subquery must select
+// exactly one field.
+func (f ArrayField) InQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? IN (?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// NotInQuery returns an 'X NOT IN (subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f ArrayField) NotInQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? NOT IN (?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of an ArrayField.
 func (f ArrayField) String() string {
@@ -283,3 +283,87 @@ func TestArrayField_Predicates(t *testing.T) {
 		})
 	}
 }
+
+func TestArrayField_Functions(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	films := &TableInfo{Schema: "public", Name: "film"}
+	features := NewArrayField("special_features", films)
+	tags := NewStringArrayField("tags", films)
+	ratings := NewNumberArrayField("ratings", films)
+	tests := []TT{
+		{"At", features.At(1), "film.special_features[?]", []interface{}{1}},
+		{"At expression", features.Append("x").At(1), "(ARRAY_APPEND(film.special_features, ?))[?]", []interface{}{"x", 1}},
+		{"At literal", Array([]int{1, 2}).At(2), "(ARRAY[?, ?])[?]", []interface{}{1, 2, 2}},
+		{"Slice", features.Slice(2, 3), "film.special_features[?:?]", []interface{}{2, 3}},
+		{"Length", features.Length(), "ARRAY_LENGTH(film.special_features, 1)", nil},
+		{"Cardinality", features.Cardinality().GtInt(0), "CARDINALITY(film.special_features) > ?", []interface{}{0}},
+		{"Prepend", features.Prepend("x"), "ARRAY_PREPEND(?, film.special_features)", []interface{}{"x"}},
+		{"Remove", features.Remove("x"), "ARRAY_REMOVE(film.special_features, ?)", []interface{}{"x"}},
+		{"Position", features.Position("x"), "ARRAY_POSITION(film.special_features, ?)", []interface{}{"x"}},
+		{"StringArrayField At", tags.At(1).Upper(), "UPPER(film.tags[?])", []interface{}{1}},
+		{"StringArrayField Slice At", tags.Slice(1, 2).At(1).EqString("a"), "(film.tags[?:?])[?] = ?", []interface{}{1, 2, 1, "a"}},
+		{"NumberArrayField At", ratings.At(1).AddInt(1), "film.ratings[?] + ?", []interface{}{1, 1}},
+		{"NumberArray literal", NumberArray([]int64{1}).Append(2), "ARRAY_APPEND(ARRAY[?], ?)", []interface{}{int64(1), 2}},
+		{"EqAny", Int(1).EqAny(ratings.ArrayField), "? = ANY(film.ratings)", []interface{}{1}},
+		{"GtAll", NewNumberField("score", films).GtAll(Array([]int{1, 2})), "film.score > ALL(ARRAY[?, ?])", []interface{}{1, 2}},
+		{"StringField EqAny", NewStringField("title", films).EqAny(tags.ArrayField), "film.title = ANY(film.tags)", nil},
+		{"StringField NeAll", String("x").NeAll(Array([]string{"a"})), "? <> ALL(ARRAY[?])", []interface{}{"x", "a"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestArrayField_Assignments(t *testing.T) {
+	is := is.New(t)
+	films := &TableInfo{Schema: "public", Name: "film"}
+	features := NewArrayField("special_features", films)
+	tags := NewStringArrayField("tags", films)
+	assignments := Assignments{
+		features.SetAppend("Trailers"),
+		features.SetRemove("Deleted Scenes"),
+		tags.Set(tags.Append("new")),
+	}
+	buf := &strings.Builder{}
+	var args []interface{}
+	assignments.AppendSQLExclude(buf, &args, []string{"film"})
+	is.Equal("special_features = ARRAY_APPEND(special_features, ?)"+
+		", special_features = ARRAY_REMOVE(special_features, ?)"+
+		", tags = ARRAY_APPEND(tags, ?)", buf.String())
+	is.Equal([]interface{}{"Trailers", "Deleted Scenes", "new"}, args)
+}
+
+func TestUnnestTable(t *testing.T) {
+	is := is.New(t)
+	films := &TableInfo{Schema: "public", Name: "film"}
+	tags := NewStringArrayField("tags", films)
+	tag := Unnest(tags).As("tag").WithOrdinality()
+	q := From(films).
+		Join(tag, Bool(true)).
+		Select(NewNumberField("film_id", films), tag.StringValue(), tag.Index()).
+		Where(tag.StringValue().EqString("classic"))
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal("SELECT film.film_id, tag.tag, tag.ordinality"+
+		" FROM public.film JOIN UNNEST(film.tags) WITH ORDINALITY AS tag ON $1"+
+		" WHERE tag.tag = $2", gotQuery)
+	is.Equal([]interface{}{true, "classic"}, gotArgs)
+
+	q = From(Unnest(Array([]int{1, 2}))).Select(Unnest(nil).NumberValue())
+	gotQuery, gotArgs = q.ToSQL()
+	is.Equal("SELECT unnest.unnest FROM UNNEST(ARRAY[$1, $2]) AS unnest", gotQuery)
+	is.Equal([]interface{}{1, 2}, gotArgs)
+}
@@ -98,7 +98,6 @@ func (q BaseQuery) With(CTEs ...CTE) BaseQuery {
 func (q BaseQuery) From(table Table) SelectQuery {
 	return SelectQuery{
 		FromTable: table,
-		Alias:     RandomString(8),
 		CTEs:      q.CTEs,
 		DB:        q.DB,
 		Log:       q.Log,
@@ -110,7 +109,6 @@ func (q BaseQuery) From(table Table) SelectQuery {
 func (q BaseQuery) Select(fields ...Field) SelectQuery {
 	return SelectQuery{
 		SelectFields: fields,
-		Alias:        RandomString(8),
 		CTEs:         q.CTEs,
 		DB:           q.DB,
 		Log:          q.Log,
@@ -122,7 +120,6 @@ func (q BaseQuery) Select(fields ...Field) SelectQuery {
 func (q BaseQuery) SelectOne() SelectQuery {
 	return SelectQuery{
 		SelectFields: Fields{FieldLiteral("1")},
-		Alias:        RandomString(8),
 		CTEs:         q.CTEs,
 		DB:           q.DB,
 		Log:          q.Log,
@@ -134,7 +131,6 @@ func (q BaseQuery) SelectOne() SelectQuery {
 func (q BaseQuery) SelectAll() SelectQuery {
 	return SelectQuery{
 		SelectFields: Fields{FieldLiteral("*")},
-		Alias:        RandomString(8),
 		CTEs:         q.CTEs,
 		DB:           q.DB,
 		Log:          q.Log,
@@ -146,7 +142,6 @@ func (q BaseQuery) SelectAll() SelectQuery {
 func (q BaseQuery) SelectCount() SelectQuery {
 	return SelectQuery{
 		SelectFields: Fields{FieldLiteral("COUNT(*)")},
-		Alias:        RandomString(8),
 		CTEs:         q.CTEs,
 		DB:           q.DB,
 		Log:          q.Log,
@@ -159,7 +154,6 @@ func (q BaseQuery) SelectDistinct(fields ...Field) SelectQuery {
 	return SelectQuery{
 		SelectType:   SelectTypeDistinct,
 		SelectFields: fields,
-		Alias:        RandomString(8),
 		CTEs:         q.CTEs,
 		DB:           q.DB,
 		Log:          q.Log,
@@ -174,7 +168,6 @@ func (q BaseQuery) SelectDistinctOn(distinctFields ...Field) func(...Field) Sele
 			SelectType:   SelectTypeDistinctOn,
 			SelectFields: fields,
 			DistinctOn:   distinctFields,
-			Alias:        RandomString(8),
 			CTEs:         q.CTEs,
 			DB:           q.DB,
 			Log:          q.Log,
@@ -188,7 +181,6 @@ func (q BaseQuery) Selectx(mapper func(*Row), accumulator func()) SelectQuery {
 	return SelectQuery{
 		Mapper:      mapper,
 		Accumulator: accumulator,
-		Alias:       RandomString(8),
 		CTEs:        q.CTEs,
 		DB:          q.DB,
 		Log:         q.Log,
@@ -200,7 +192,6 @@ func (q BaseQuery) Selectx(mapper func(*Row), accumulator func()) SelectQuery {
 func (q BaseQuery) SelectRowx(mapper func(*Row)) SelectQuery {
 	return SelectQuery{
 		Mapper:  mapper,
-		Alias:   RandomString(8),
 		CTEs:    q.CTEs,
 		DB:      q.DB,
 		Log:     q.Log,
@@ -212,7 +203,6 @@ func (q BaseQuery) SelectRowx(mapper func(*Row)) SelectQuery {
 func (q BaseQuery) InsertInto(table BaseTable) InsertQuery {
 	return InsertQuery{
 		IntoTable: table,
-		Alias:     RandomString(8),
 		CTEs:      q.CTEs,
 		DB:        q.DB,
 		Log:       q.Log,
@@ -224,7 +214,6 @@ func (q BaseQuery) InsertInto(table BaseTable) InsertQuery {
 func (q BaseQuery) Update(table BaseTable) UpdateQuery {
 	return UpdateQuery{
 		UpdateTable: table,
-		Alias:       RandomString(8),
 		CTEs:        q.CTEs,
 		DB:          q.DB,
 		Log:         q.Log,
@@ -236,10 +225,62 @@ func (q BaseQuery) Update(table BaseTable) UpdateQuery {
 func (q BaseQuery) DeleteFrom(table BaseTable) DeleteQuery {
 	return DeleteQuery{
 		FromTable: table,
-		Alias:     RandomString(8),
 		CTEs:      q.CTEs,
 		DB:        q.DB,
 		Log:       q.Log,
 		LogFlag:   q.LogFlag,
 	}
 }
+
+// MergeInto transforms the BaseQuery into a MergeQuery.
+func (q BaseQuery) MergeInto(table BaseTable) MergeQuery {
+	return MergeQuery{
+		IntoTable: table,
+		CTEs:      q.CTEs,
+		DB:        q.DB,
+		Log:       q.Log,
+		LogFlag:   q.LogFlag,
+	}
+}
+
+// variadicQuery transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) variadicQuery(operator VariadicQueryOperator, queries []Query) VariadicQuery {
+	return VariadicQuery{
+		Operator: operator,
+		Queries:  queries,
+		CTEs:     q.CTEs,
+		DB:       q.DB,
+		Log:      q.Log,
+		LogFlag:  q.LogFlag,
+	}
+}
+
+// Union transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) Union(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryUnion, queries)
+}
+
+// UnionAll transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) UnionAll(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryUnionAll, queries)
+}
+
+// Intersect transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) Intersect(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryIntersect, queries)
+}
+
+// IntersectAll transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) IntersectAll(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryIntersectAll, queries)
+}
+
+// Except transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) Except(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryExcept, queries)
+}
+
+// ExceptAll transforms the BaseQuery into a VariadicQuery.
+func (q BaseQuery) ExceptAll(queries ...Query) VariadicQuery {
+	return q.variadicQuery(QueryExceptAll, queries)
+}
@@ -26,33 +26,7 @@ func (f BinaryField) AppendSQLExclude(buf *strings.Builder, args *[]interface{},
 		*args = append(*args, *f.value)
 	default:
 		// 2) BYTEA column
-		tableQualifier := f.table.GetAlias()
-		if tableQualifier == "" {
-			tableQualifier = f.table.GetName()
-		}
-		for _, excludedTableQualifier := range excludedTableQualifiers {
-			if tableQualifier == excludedTableQualifier {
-				tableQualifier = ""
-				break
-			}
-		}
-		if tableQualifier != "" {
-			if strings.ContainsAny(tableQualifier, " \t") {
-				buf.WriteString(`"`)
-				buf.WriteString(tableQualifier)
-				buf.WriteString(`".`)
-			} else {
-				buf.WriteString(tableQualifier)
-				buf.WriteString(".")
-			}
-		}
-		if strings.ContainsAny(f.name, " \t") {
-			buf.WriteString(`"`)
-			buf.WriteString(f.name)
-			buf.WriteString(`"`)
-		} else {
-			buf.WriteString(f.name)
-		}
+		appendColumn(buf, f.table, f.name, excludedTableQualifiers)
 	}
 }
 
@@ -0,0 +1,142 @@
+package sq
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestBulkUpdateQuery_Queries(t *testing.T) {
+	type TT struct {
+		description string
+		q           BulkUpdateQuery
+		wantQueries []string
+		wantArgs    [][]interface{}
+	}
+	u, ur := USERS().As("u"), USER_ROLES().As("ur")
+	rows := [][]interface{}{
+		{1, "alice", "alice@email.com"},
+		{2, "bob", "bob@email.com"},
+		{3, "eve", "eve@email.com"},
+	}
+	tests := []TT{
+		{
+			"single query",
+			Update(u).BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME, u.EMAIL}, rows),
+			[]string{
+				"UPDATE public.users AS u SET displayname = bulk.displayname, email = bulk.email" +
+					" FROM (VALUES ($1::BIGINT, $2::TEXT, $3::TEXT), ($4, $5, $6), ($7, $8, $9)) AS bulk (user_id, displayname, email)" +
+					" WHERE u.user_id = bulk.user_id",
+			},
+			[][]interface{}{
+				{1, "alice", "alice@email.com", 2, "bob", "bob@email.com", 3, "eve", "eve@email.com"},
+			},
+		},
+		{
+			"chunked with extra predicates",
+			Update(u).
+				Where(u.EMAIL.NeString("")).
+				BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME}, [][]interface{}{{1, "alice"}, {2, "bob"}, {3, "eve"}}).
+				Chunk(2),
+			[]string{
+				"UPDATE public.users AS u SET displayname = bulk.displayname" +
+					" FROM (VALUES ($1::BIGINT, $2::TEXT), ($3, $4)) AS bulk (user_id, displayname)" +
+					" WHERE u.email <> $5 AND u.user_id = bulk.user_id",
+				"UPDATE public.users AS u SET displayname = bulk.displayname" +
+					" FROM (VALUES ($1::BIGINT, $2::TEXT)) AS bulk (user_id, displayname)" +
+					" WHERE u.email <> $3 AND u.user_id = bulk.user_id",
+			},
+			[][]interface{}{
+				{1, "alice", 2, "bob", ""},
+				{3, "eve", ""},
+			},
+		},
+		{
+			"existing FROM and Cast",
+			Update(u).
+				From(ur).
+				Where(ur.USER_ID.Eq(u.USER_ID)).
+				BulkUpdate(Fields{u.USER_ID, ur.ROLE}, Fields{u.DISPLAYNAME}, [][]interface{}{{1, "student", "alice"}}).
+				Cast("INT", "role_enum"),
+			[]string{
+				"UPDATE public.users AS u SET displayname = bulk.displayname" +
+					" FROM public.user_roles AS ur CROSS JOIN (VALUES ($1::INT, $2::role_enum, $3::TEXT)) AS bulk (user_id, role, displayname)" +
+					" WHERE ur.user_id = u.user_id AND u.user_id = bulk.user_id AND ur.role = bulk.role",
+			},
+			[][]interface{}{
+				{1, "student", "alice"},
+			},
+		},
+		func() TT {
+			tbl := &TableInfo{Schema: "public", Name: "orders"}
+			id, order := NewNumberField("id", tbl), NewStringField("order", tbl)
+			return TT{
+				"reserved column names",
+				Update(tbl).BulkUpdate(Fields{id}, Fields{order}, [][]interface{}{{1, "a"}}),
+				[]string{
+					`UPDATE public.orders SET "order" = bulk."order"` +
+						` FROM (VALUES ($1::BIGINT, $2::TEXT)) AS bulk (id, "order") WHERE orders.id = bulk.id`,
+				},
+				[][]interface{}{{1, "a"}},
+			}
+		}(),
+		{
+			"no rows",
+			Update(u).BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME}, nil),
+			nil,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			var gotQueries []string
+			var gotArgs [][]interface{}
+			queries, err := tt.q.Queries()
+			is.NoErr(err)
+			for _, q := range queries {
+				query, args := q.ToSQL()
+				gotQueries = append(gotQueries, query)
+				gotArgs = append(gotArgs, args)
+			}
+			is.Equal(tt.wantQueries, gotQueries)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestBulkUpdateQuery_ChunkSize(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	rows := make([][]interface{}, 40000)
+	for i := range rows {
+		rows[i] = []interface{}{i, "name"}
+	}
+	queries, err := Update(u).BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME}, rows).Queries()
+	is.NoErr(err)
+	is.Equal(2, len(queries))
+	for _, q := range queries {
+		_, args := q.ToSQL()
+		is.True(len(args) <= maxPlaceholders)
+	}
+
+	// The UpdateQuery leaves no room for any rows
+	ids := make([]int, maxPlaceholders)
+	_, err = Update(u).Where(u.USER_ID.In(ids)).BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME}, rows).Queries()
+	is.True(err != nil)
+	_, err = Update(u).Where(u.USER_ID.In(ids)).BulkUpdate(Fields{u.USER_ID}, Fields{u.DISPLAYNAME}, rows).Exec(nil, 0)
+	is.True(err != nil)
+}
+
+func TestBulkUpdateQuery_Fields(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	// keyFields has spare capacity, which must not be written into
+	fields := Fields{u.USER_ID, u.EMAIL}
+	keyFields := fields[:1]
+	_, err := Update(u).BulkUpdate(keyFields, Fields{u.DISPLAYNAME}, [][]interface{}{{1, "alice"}}).Queries()
+	is.NoErr(err)
+	is.Equal("email", fields[1].GetName())
+}
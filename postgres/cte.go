@@ -12,7 +12,7 @@ type CTE struct {
 
 // ToSQL simply returns the name of the CTE.
 func (cte CTE) AppendSQL(buf *strings.Builder, args *[]interface{}) {
-	buf.WriteString(cte.Name)
+	appendIdentifier(buf, cte.Name, false)
 }
 
 // NewCTE creates a new CTE.
@@ -50,10 +50,46 @@ func (cte CTE) GetName() string {
 // its own name to the fieldName.
 func (cte CTE) Get(fieldName string) CustomField {
 	return CustomField{
-		Format: cte.Name + "." + fieldName,
+		Format: qualify(cte.Name, fieldName),
 	}
 }
 
+// NumberField returns the NumberField column of the CTE identified by name. The
+// column must appear in the select list of the CTE's query.
+func (cte CTE) NumberField(name string) NumberField {
+	return derivedNumberField(cte, cte.Query, cte.Columns, name)
+}
+
+// StringField returns the StringField column of the CTE identified by name. The
+// column must appear in the select list of the CTE's query.
+func (cte CTE) StringField(name string) StringField {
+	return derivedStringField(cte, cte.Query, cte.Columns, name)
+}
+
+// TimeField returns the TimeField column of the CTE identified by name. The
+// column must appear in the select list of the CTE's query.
+func (cte CTE) TimeField(name string) TimeField {
+	return derivedTimeField(cte, cte.Query, cte.Columns, name)
+}
+
+// BooleanField returns the BooleanField column of the CTE identified by name.
+// The column must appear in the select list of the CTE's query.
+func (cte CTE) BooleanField(name string) BooleanField {
+	return derivedBooleanField(cte, cte.Query, cte.Columns, name)
+}
+
+// JSONField returns the JSONField column of the CTE identified by name. The
+// column must appear in the select list of the CTE's query.
+func (cte CTE) JSONField(name string) JSONField {
+	return derivedJSONField(cte, cte.Query, cte.Columns, name)
+}
+
+// ArrayField returns the ArrayField column of the CTE identified by name. The
+// column must appear in the select list of the CTE's query.
+func (cte CTE) ArrayField(name string) ArrayField {
+	return derivedArrayField(cte, cte.Query, cte.Columns, name)
+}
+
 // CTEs represents a list of CTEs
 type CTEs []CTE
 
@@ -77,10 +113,10 @@ func (ctes CTEs) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 		if i > 0 {
 			buf.WriteString(", ")
 		}
-		buf.WriteString(cte.Name)
+		appendIdentifier(buf, cte.Name, false)
 		if len(cte.Columns) > 0 {
 			buf.WriteString(" (")
-			buf.WriteString(strings.Join(cte.Columns, ", "))
+			appendIdentifiers(buf, cte.Columns)
 			buf.WriteString(")")
 		}
 		buf.WriteString(" AS (")
@@ -97,22 +133,26 @@ func (ctes CTEs) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 // on.
 func (cte CTE) As(alias string) AliasedCTE {
 	return AliasedCTE{
-		Name:  cte.Name,
-		Alias: alias,
+		Name:    cte.Name,
+		Alias:   alias,
+		Query:   cte.Query,
+		Columns: cte.Columns,
 	}
 }
 
 // AliasedCTE is an aliased version of a CTE derived from a parent CTE.
 type AliasedCTE struct {
-	Name  string
-	Alias string
+	Name    string
+	Alias   string
+	Query   Query
+	Columns []string
 }
 
 // ToSQL returns the name of the parent CTE the AliasedCTE was derived from.
 // There is no need to provide the alias, as the caller of ToSQL() should be
 // responsible for calling GetAlias() as well.
 func (cte AliasedCTE) AppendSQL(buf *strings.Builder, _ *[]interface{}) {
-	buf.WriteString(cte.Name)
+	appendIdentifier(buf, cte.Name, false)
 }
 
 // GetAlias implements the Table interface. It returns the alias of the
@@ -132,6 +172,42 @@ func (cte AliasedCTE) GetName() string {
 // AliasedCTE simply prepends its own alias to the fieldName.
 func (cte AliasedCTE) Get(fieldName string) CustomField {
 	return CustomField{
-		Format: cte.Alias + "." + fieldName,
+		Format: qualify(cte.Alias, fieldName),
 	}
 }
+
+// NumberField returns the NumberField column of the AliasedCTE identified by
+// name. The column must appear in the select list of the parent CTE's query.
+func (cte AliasedCTE) NumberField(name string) NumberField {
+	return derivedNumberField(cte, cte.Query, cte.Columns, name)
+}
+
+// StringField returns the StringField column of the AliasedCTE identified by
+// name. The column must appear in the select list of the parent CTE's query.
+func (cte AliasedCTE) StringField(name string) StringField {
+	return derivedStringField(cte, cte.Query, cte.Columns, name)
+}
+
+// TimeField returns the TimeField column of the AliasedCTE identified by name.
+// The column must appear in the select list of the parent CTE's query.
+func (cte AliasedCTE) TimeField(name string) TimeField {
+	return derivedTimeField(cte, cte.Query, cte.Columns, name)
+}
+
+// BooleanField returns the BooleanField column of the AliasedCTE identified by
+// name. The column must appear in the select list of the parent CTE's query.
+func (cte AliasedCTE) BooleanField(name string) BooleanField {
+	return derivedBooleanField(cte, cte.Query, cte.Columns, name)
+}
+
+// JSONField returns the JSONField column of the AliasedCTE identified by name.
+// The column must appear in the select list of the parent CTE's query.
+func (cte AliasedCTE) JSONField(name string) JSONField {
+	return derivedJSONField(cte, cte.Query, cte.Columns, name)
+}
+
+// ArrayField returns the ArrayField column of the AliasedCTE identified by
+// name. The column must appear in the select list of the parent CTE's query.
+func (cte AliasedCTE) ArrayField(name string) ArrayField {
+	return derivedArrayField(cte, cte.Query, cte.Columns, name)
+}
@@ -24,6 +24,21 @@ func (q CustomQuery) ToSQL() (string, []interface{}) {
 	return buf.String(), args
 }
 
+// ToSQLInline marshals the CustomQuery into a query string with the values inlined
+// as SQL literals instead of bind parameters. It is meant for where bind
+// parameters are not allowed e.g. in DDL, otherwise use ToSQL. It returns
+// an error if a value cannot be written as a literal.
+func (q CustomQuery) ToSQLInline() (string, error) {
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args)
+	query, err := questionInterpolate(buf.String(), args)
+	if err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
 // AppendSQL marshals the CustomQuery into an SQL query.
 func (q CustomQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	ExpandValues(buf, args, nil, q.Format, q.Values)
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -44,6 +43,22 @@ func (q DeleteQuery) ToSQL() (string, []interface{}) {
 	return buf.String(), args
 }
 
+// ToSQLInline marshals the DeleteQuery into a query string with the values inlined
+// as SQL literals instead of bind parameters. It is meant for where bind
+// parameters are not allowed e.g. in DDL, otherwise use ToSQL. It returns
+// an error if a value cannot be written as a literal.
+func (q DeleteQuery) ToSQLInline() (string, error) {
+	q.Log = nil
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args)
+	query, err := dollarInterpolate(buf.String(), args)
+	if err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
 func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	// WITH
 	if len(q.CTEs) > 0 {
@@ -55,36 +70,12 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	if q.FromTable == nil {
 		buf.WriteString("NULL")
 	} else {
-		switch v := q.FromTable.(type) {
-		case Query:
-			buf.WriteString("(")
-			v.NestThis().AppendSQL(buf, args)
-			buf.WriteString(")")
-		default:
-			q.FromTable.AppendSQL(buf, args)
-		}
-		alias := q.FromTable.GetAlias()
-		if alias != "" {
-			buf.WriteString(" AS ")
-			buf.WriteString(alias)
-		}
+		appendTable(buf, args, q.FromTable)
 	}
 	// USING
 	if q.UsingTable != nil {
 		buf.WriteString(" USING ")
-		switch v := q.UsingTable.(type) {
-		case Query:
-			buf.WriteString("(")
-			v.NestThis().AppendSQL(buf, args)
-			buf.WriteString(")")
-		default:
-			q.FromTable.AppendSQL(buf, args)
-		}
-		alias := q.UsingTable.GetAlias()
-		if alias != "" {
-			buf.WriteString(" AS ")
-			buf.WriteString(alias)
-		}
+		appendTable(buf, args, q.UsingTable)
 	}
 	// JOIN
 	if len(q.JoinTables) > 0 {
@@ -142,10 +133,52 @@ func (q DeleteQuery) NestThis() Query {
 
 func (q DeleteQuery) Get(fieldName string) CustomField {
 	return CustomField{
-		Format: q.Alias + "." + fieldName,
+		Format: qualify(derivedAlias(q), fieldName),
 	}
 }
 
+// NumberField returns the NumberField column of the DeleteQuery identified by
+// name, for use when the DeleteQuery is a CTE or derived table. The column must
+// appear in the RETURNING list.
+func (q DeleteQuery) NumberField(name string) NumberField {
+	return derivedNumberField(q, q, nil, name)
+}
+
+// StringField returns the StringField column of the DeleteQuery identified by
+// name, for use when the DeleteQuery is a CTE or derived table. The column must
+// appear in the RETURNING list.
+func (q DeleteQuery) StringField(name string) StringField {
+	return derivedStringField(q, q, nil, name)
+}
+
+// TimeField returns the TimeField column of the DeleteQuery identified by name,
+// for use when the DeleteQuery is a CTE or derived table. The column must
+// appear in the RETURNING list.
+func (q DeleteQuery) TimeField(name string) TimeField {
+	return derivedTimeField(q, q, nil, name)
+}
+
+// BooleanField returns the BooleanField column of the DeleteQuery identified by
+// name, for use when the DeleteQuery is a CTE or derived table. The column must
+// appear in the RETURNING list.
+func (q DeleteQuery) BooleanField(name string) BooleanField {
+	return derivedBooleanField(q, q, nil, name)
+}
+
+// JSONField returns the JSONField column of the DeleteQuery identified by name,
+// for use when the DeleteQuery is a CTE or derived table. The column must
+// appear in the RETURNING list.
+func (q DeleteQuery) JSONField(name string) JSONField {
+	return derivedJSONField(q, q, nil, name)
+}
+
+// ArrayField returns the ArrayField column of the DeleteQuery identified by
+// name, for use when the DeleteQuery is a CTE or derived table. The column must
+// appear in the RETURNING list.
+func (q DeleteQuery) ArrayField(name string) ArrayField {
+	return derivedArrayField(q, q, nil, name)
+}
+
 func (q DeleteQuery) As(alias string) DeleteQuery {
 	q.Alias = alias
 	return q
@@ -154,7 +187,6 @@ func (q DeleteQuery) As(alias string) DeleteQuery {
 func DeleteFrom(table BaseTable) DeleteQuery {
 	return DeleteQuery{
 		FromTable: table,
-		Alias:     RandomString(8),
 	}
 }
 
@@ -273,20 +305,29 @@ func (q DeleteQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	if q.Mapper == nil {
 		return fmt.Errorf("Cannot call Fetch without a mapper")
 	}
+	r := &Row{}
+	buf := &strings.Builder{}
+	var args []interface{}
 	logBuf := &strings.Builder{}
 	start := time.Now()
 	var rowcount int
 	defer func() {
-		if r := recover(); r != nil {
-			switch v := r.(type) {
+		if p := recover(); p != nil {
+			switch v := p.(type) {
 			case ExitCode:
 				if v != ExitPeacefully {
 					err = v
 				}
+				return
 			case error:
 				err = v
 			default:
-				err = fmt.Errorf("%#v", r)
+				err = fmt.Errorf("%#v", p)
+			}
+			// a panic in the first call of the mapper happens before the
+			// query is built, so there is no query to wrap the error with
+			if buf.Len() > 0 {
+				err = newQueryError("DELETE", buf.String(), args, r.fields, err)
 			}
 			return
 		}
@@ -313,40 +354,32 @@ func (q DeleteQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			}
 		}
 	}()
-	r := &Row{}
 	q.Mapper(r)
 	q.ReturningFields = r.fields
-	tmpbuf := &strings.Builder{}
-	var tmpargs []interface{}
 	q.LogSkip += 1
-	q.AppendSQL(tmpbuf, &tmpargs)
+	q.AppendSQL(buf, &args)
+	if err = ArgsError(args); err != nil {
+		return newQueryError("DELETE", buf.String(), args, nil, err)
+	}
 	if ctx == nil {
-		r.rows, err = db.Query(tmpbuf.String(), tmpargs...)
+		r.rows, err = db.Query(buf.String(), args...)
 	} else {
-		r.rows, err = db.QueryContext(ctx, tmpbuf.String(), tmpargs...)
+		r.rows, err = db.QueryContext(ctx, buf.String(), args...)
 	}
 	if err != nil {
-		return err
+		return newQueryError("DELETE", buf.String(), args, nil, err)
 	}
+	tmpbuf := &strings.Builder{}
+	var tmpargs []interface{}
 	defer r.rows.Close()
 	if len(r.dest) == 0 {
 		return nil
 	}
 	for r.rows.Next() {
 		rowcount++
-		err = r.rows.Scan(r.dest...)
+		err = r.scan()
 		if err != nil {
-			errbuf := &strings.Builder{}
-			for i := range r.dest {
-				tmpbuf.Reset()
-				tmpargs = tmpargs[:0]
-				r.fields[i].AppendSQLExclude(tmpbuf, &tmpargs, nil)
-				errbuf.WriteString("\n" +
-					strconv.Itoa(i) + ") " +
-					DollarInterpolate(tmpbuf.String(), tmpargs...) + " => " +
-					reflect.TypeOf(r.dest[i]).String())
-			}
-			return fmt.Errorf("Please check if your mapper function is correct:%s\n%w", errbuf.String(), err)
+			return newQueryError("DELETE", buf.String(), args, r.fields, err)
 		}
 		if q.Log != nil && Lresults&q.LogFlag != 0 && rowcount <= 5 {
 			logBuf.WriteString("\n----[ Row ")
@@ -373,9 +406,35 @@ func (q DeleteQuery) FetchContext(ctx context.Context, db DB) (err error) {
 		return sql.ErrNoRows
 	}
 	if e := r.rows.Close(); e != nil {
-		return e
+		return newQueryError("DELETE", buf.String(), args, nil, e)
+	}
+	return newQueryError("DELETE", buf.String(), args, nil, r.rows.Err())
+}
+
+// Cursor runs the DeleteQuery with the given DB and context and returns a Cursor
+// that maps its rows one at a time with the mapper. If the mapper is nil, the
+// mapper set by Returningx or ReturningRowx is used instead. The context may be nil.
+func (q DeleteQuery) Cursor(ctx context.Context, db DB, mapper func(*Row)) (*Cursor, error) {
+	if db == nil {
+		if q.DB == nil {
+			return nil, errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	if mapper == nil {
+		mapper = q.Mapper
+	}
+	if mapper == nil {
+		return nil, fmt.Errorf("Cannot call Cursor without a mapper")
 	}
-	return r.rows.Err()
+	r := &Row{}
+	mapper(r)
+	q.ReturningFields = r.fields
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.LogSkip += 1
+	q.AppendSQL(buf, &args)
+	return openCursor(ctx, db, "DELETE", buf.String(), args, r, mapper, q.Log, q.LogFlag)
 }
 
 func (q DeleteQuery) Exec(db DB, flag ExecFlag) (rowsAffected int64, err error) {
@@ -418,18 +477,21 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	var tmpargs []interface{}
 	q.LogSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs)
+	if err = ArgsError(tmpargs); err != nil {
+		return rowsAffected, newQueryError("DELETE", tmpbuf.String(), tmpargs, nil, err)
+	}
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
 		res, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return rowsAffected, err
+		return rowsAffected, newQueryError("DELETE", tmpbuf.String(), tmpargs, nil, err)
 	}
 	if res != nil && ErowsAffected&flag != 0 {
 		rowsAffected, err = res.RowsAffected()
 		if err != nil {
-			return rowsAffected, err
+			return rowsAffected, newQueryError("DELETE", tmpbuf.String(), tmpargs, nil, err)
 		}
 	}
 	return rowsAffected, nil
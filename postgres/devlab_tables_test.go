@@ -24,8 +24,9 @@ type TABLE_APPLICATIONS struct {
 // APPLICATIONS creates an instance of the public.applications table.
 func APPLICATIONS() TABLE_APPLICATIONS {
 	tbl := TABLE_APPLICATIONS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "applications",
+		Schema:        "public",
+		Name:          "applications",
+		CaseSensitive: true,
 	}}
 	tbl.APPLICATION_DATA = NewJSONField("application_data", tbl.TableInfo)
 	tbl.APPLICATION_FORM_ID = NewNumberField("application_form_id", tbl.TableInfo)
@@ -60,8 +61,9 @@ type TABLE_APPLICATIONS_STATUS_ENUM struct {
 // APPLICATIONS_STATUS_ENUM creates an instance of the public.applications_status_enum table.
 func APPLICATIONS_STATUS_ENUM() TABLE_APPLICATIONS_STATUS_ENUM {
 	tbl := TABLE_APPLICATIONS_STATUS_ENUM{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "applications_status_enum",
+		Schema:        "public",
+		Name:          "applications_status_enum",
+		CaseSensitive: true,
 	}}
 	tbl.STATUS = NewStringField("status", tbl.TableInfo)
 	return tbl
@@ -83,8 +85,9 @@ type TABLE_COHORT_ENUM struct {
 // COHORT_ENUM creates an instance of the public.cohort_enum table.
 func COHORT_ENUM() TABLE_COHORT_ENUM {
 	tbl := TABLE_COHORT_ENUM{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "cohort_enum",
+		Schema:        "public",
+		Name:          "cohort_enum",
+		CaseSensitive: true,
 	}}
 	tbl.COHORT = NewStringField("cohort", tbl.TableInfo)
 	tbl.INSERTION_ORDER = NewNumberField("insertion_order", tbl.TableInfo)
@@ -115,8 +118,9 @@ type TABLE_FEEDBACK_ON_TEAMS struct {
 // FEEDBACK_ON_TEAMS creates an instance of the public.feedback_on_teams table.
 func FEEDBACK_ON_TEAMS() TABLE_FEEDBACK_ON_TEAMS {
 	tbl := TABLE_FEEDBACK_ON_TEAMS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "feedback_on_teams",
+		Schema:        "public",
+		Name:          "feedback_on_teams",
+		CaseSensitive: true,
 	}}
 	tbl.CREATED_AT = NewTimeField("created_at", tbl.TableInfo)
 	tbl.DELETED_AT = NewTimeField("deleted_at", tbl.TableInfo)
@@ -155,8 +159,9 @@ type TABLE_FEEDBACK_ON_USERS struct {
 // FEEDBACK_ON_USERS creates an instance of the public.feedback_on_users table.
 func FEEDBACK_ON_USERS() TABLE_FEEDBACK_ON_USERS {
 	tbl := TABLE_FEEDBACK_ON_USERS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "feedback_on_users",
+		Schema:        "public",
+		Name:          "feedback_on_users",
+		CaseSensitive: true,
 	}}
 	tbl.CREATED_AT = NewTimeField("created_at", tbl.TableInfo)
 	tbl.DELETED_AT = NewTimeField("deleted_at", tbl.TableInfo)
@@ -193,8 +198,9 @@ type TABLE_FORMS struct {
 // FORMS creates an instance of the public.forms table.
 func FORMS() TABLE_FORMS {
 	tbl := TABLE_FORMS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "forms",
+		Schema:        "public",
+		Name:          "forms",
+		CaseSensitive: true,
 	}}
 	tbl.CREATED_AT = NewTimeField("created_at", tbl.TableInfo)
 	tbl.DELETED_AT = NewTimeField("deleted_at", tbl.TableInfo)
@@ -223,8 +229,9 @@ type TABLE_FORMS_AUTHORIZED_ROLES struct {
 // FORMS_AUTHORIZED_ROLES creates an instance of the public.forms_authorized_roles table.
 func FORMS_AUTHORIZED_ROLES() TABLE_FORMS_AUTHORIZED_ROLES {
 	tbl := TABLE_FORMS_AUTHORIZED_ROLES{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "forms_authorized_roles",
+		Schema:        "public",
+		Name:          "forms_authorized_roles",
+		CaseSensitive: true,
 	}}
 	tbl.FORM_ID = NewNumberField("form_id", tbl.TableInfo)
 	tbl.ROLE = NewStringField("role", tbl.TableInfo)
@@ -252,8 +259,9 @@ type TABLE_MEDIA struct {
 // MEDIA creates an instance of the public.media table.
 func MEDIA() TABLE_MEDIA {
 	tbl := TABLE_MEDIA{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "media",
+		Schema:        "public",
+		Name:          "media",
+		CaseSensitive: true,
 	}}
 	tbl.CREATED_AT = NewTimeField("created_at", tbl.TableInfo)
 	tbl.DATA = NewBinaryField("data", tbl.TableInfo)
@@ -280,8 +288,9 @@ type TABLE_MILESTONE_ENUM struct {
 // MILESTONE_ENUM creates an instance of the public.milestone_enum table.
 func MILESTONE_ENUM() TABLE_MILESTONE_ENUM {
 	tbl := TABLE_MILESTONE_ENUM{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "milestone_enum",
+		Schema:        "public",
+		Name:          "milestone_enum",
+		CaseSensitive: true,
 	}}
 	tbl.MILESTONE = NewStringField("milestone", tbl.TableInfo)
 	return tbl
@@ -302,8 +311,9 @@ type TABLE_MIME_TYPE_ENUM struct {
 // MIME_TYPE_ENUM creates an instance of the public.mime_type_enum table.
 func MIME_TYPE_ENUM() TABLE_MIME_TYPE_ENUM {
 	tbl := TABLE_MIME_TYPE_ENUM{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "mime_type_enum",
+		Schema:        "public",
+		Name:          "mime_type_enum",
+		CaseSensitive: true,
 	}}
 	tbl.TYPE = NewStringField("type", tbl.TableInfo)
 	return tbl
@@ -332,8 +342,9 @@ type TABLE_PERIODS struct {
 // PERIODS creates an instance of the public.periods table.
 func PERIODS() TABLE_PERIODS {
 	tbl := TABLE_PERIODS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "periods",
+		Schema:        "public",
+		Name:          "periods",
+		CaseSensitive: true,
 	}}
 	tbl.COHORT = NewStringField("cohort", tbl.TableInfo)
 	tbl.CREATED_AT = NewTimeField("created_at", tbl.TableInfo)
@@ -362,8 +373,9 @@ type TABLE_PROJECT_CATEGORY_ENUM struct {
 // PROJECT_CATEGORY_ENUM creates an instance of the public.project_category_enum table.
 func PROJECT_CATEGORY_ENUM() TABLE_PROJECT_CATEGORY_ENUM {
 	tbl := TABLE_PROJECT_CATEGORY_ENUM{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "project_category_enum",
+		Schema:        "public",
+		Name:          "project_category_enum",
+		CaseSensitive: true,
 	}}
 	tbl.PROJECT_CATEGORY = NewStringField("project_category", tbl.TableInfo)
 	return tbl
@@ -384,8 +396,9 @@ type TABLE_PROJECT_LEVEL_ENUM struct {
 // PROJECT_LEVEL_ENUM creates an instance of the public.project_level_enum table.
 func PROJECT_LEVEL_ENUM() TABLE_PROJECT_LEVEL_ENUM {
 	tbl := TABLE_PROJECT_LEVEL_ENUM{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "project_level_enum",
+		Schema:        "public",
+		Name:          "project_level_enum",
+		CaseSensitive: true,
 	}}
 	tbl.PROJECT_LEVEL = NewStringField("project_level", tbl.TableInfo)
 	return tbl
@@ -406,8 +419,9 @@ type TABLE_ROLE_ENUM struct {
 // ROLE_ENUM creates an instance of the public.role_enum table.
 func ROLE_ENUM() TABLE_ROLE_ENUM {
 	tbl := TABLE_ROLE_ENUM{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "role_enum",
+		Schema:        "public",
+		Name:          "role_enum",
+		CaseSensitive: true,
 	}}
 	tbl.ROLE = NewStringField("role", tbl.TableInfo)
 	return tbl
@@ -430,8 +444,9 @@ type TABLE_SESSIONS struct {
 // SESSIONS creates an instance of the public.sessions table.
 func SESSIONS() TABLE_SESSIONS {
 	tbl := TABLE_SESSIONS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "sessions",
+		Schema:        "public",
+		Name:          "sessions",
+		CaseSensitive: true,
 	}}
 	tbl.CREATED_AT = NewTimeField("created_at", tbl.TableInfo)
 	tbl.HASH = NewStringField("hash", tbl.TableInfo)
@@ -454,8 +469,9 @@ type TABLE_STAGE_ENUM struct {
 // STAGE_ENUM creates an instance of the public.stage_enum table.
 func STAGE_ENUM() TABLE_STAGE_ENUM {
 	tbl := TABLE_STAGE_ENUM{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "stage_enum",
+		Schema:        "public",
+		Name:          "stage_enum",
+		CaseSensitive: true,
 	}}
 	tbl.STAGE = NewStringField("stage", tbl.TableInfo)
 	return tbl
@@ -487,8 +503,9 @@ type TABLE_SUBMISSIONS struct {
 // SUBMISSIONS creates an instance of the public.submissions table.
 func SUBMISSIONS() TABLE_SUBMISSIONS {
 	tbl := TABLE_SUBMISSIONS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "submissions",
+		Schema:        "public",
+		Name:          "submissions",
+		CaseSensitive: true,
 	}}
 	tbl.CREATED_AT = NewTimeField("created_at", tbl.TableInfo)
 	tbl.DELETED_AT = NewTimeField("deleted_at", tbl.TableInfo)
@@ -521,8 +538,9 @@ type TABLE_SUBMISSIONS_CATEGORIES struct {
 // SUBMISSIONS_CATEGORIES creates an instance of the public.submissions_categories table.
 func SUBMISSIONS_CATEGORIES() TABLE_SUBMISSIONS_CATEGORIES {
 	tbl := TABLE_SUBMISSIONS_CATEGORIES{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "submissions_categories",
+		Schema:        "public",
+		Name:          "submissions_categories",
+		CaseSensitive: true,
 	}}
 	tbl.CATEGORY = NewStringField("category", tbl.TableInfo)
 	tbl.SUBMISSION_ID = NewNumberField("submission_id", tbl.TableInfo)
@@ -545,8 +563,9 @@ type TABLE_TEAM_EVALUATION_PAIRS struct {
 // TEAM_EVALUATION_PAIRS creates an instance of the public.team_evaluation_pairs table.
 func TEAM_EVALUATION_PAIRS() TABLE_TEAM_EVALUATION_PAIRS {
 	tbl := TABLE_TEAM_EVALUATION_PAIRS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "team_evaluation_pairs",
+		Schema:        "public",
+		Name:          "team_evaluation_pairs",
+		CaseSensitive: true,
 	}}
 	tbl.EVALUATEE_TEAM_ID = NewNumberField("evaluatee_team_id", tbl.TableInfo)
 	tbl.EVALUATOR_TEAM_ID = NewNumberField("evaluator_team_id", tbl.TableInfo)
@@ -577,8 +596,9 @@ type TABLE_TEAM_EVALUATIONS struct {
 // TEAM_EVALUATIONS creates an instance of the public.team_evaluations table.
 func TEAM_EVALUATIONS() TABLE_TEAM_EVALUATIONS {
 	tbl := TABLE_TEAM_EVALUATIONS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "team_evaluations",
+		Schema:        "public",
+		Name:          "team_evaluations",
+		CaseSensitive: true,
 	}}
 	tbl.CREATED_AT = NewTimeField("created_at", tbl.TableInfo)
 	tbl.DELETED_AT = NewTimeField("deleted_at", tbl.TableInfo)
@@ -619,8 +639,9 @@ type TABLE_TEAMS struct {
 // TEAMS creates an instance of the public.teams table.
 func TEAMS() TABLE_TEAMS {
 	tbl := TABLE_TEAMS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "teams",
+		Schema:        "public",
+		Name:          "teams",
+		CaseSensitive: true,
 	}}
 	tbl.ADVISER_USER_ROLE_ID = NewNumberField("adviser_user_role_id", tbl.TableInfo)
 	tbl.COHORT = NewStringField("cohort", tbl.TableInfo)
@@ -652,8 +673,9 @@ type TABLE_TEAMS_STATUS_ENUM struct {
 // TEAMS_STATUS_ENUM creates an instance of the public.teams_status_enum table.
 func TEAMS_STATUS_ENUM() TABLE_TEAMS_STATUS_ENUM {
 	tbl := TABLE_TEAMS_STATUS_ENUM{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "teams_status_enum",
+		Schema:        "public",
+		Name:          "teams_status_enum",
+		CaseSensitive: true,
 	}}
 	tbl.STATUS = NewStringField("status", tbl.TableInfo)
 	return tbl
@@ -683,8 +705,9 @@ type TABLE_USER_EVALUATIONS struct {
 // USER_EVALUATIONS creates an instance of the public.user_evaluations table.
 func USER_EVALUATIONS() TABLE_USER_EVALUATIONS {
 	tbl := TABLE_USER_EVALUATIONS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "user_evaluations",
+		Schema:        "public",
+		Name:          "user_evaluations",
+		CaseSensitive: true,
 	}}
 	tbl.CREATED_AT = NewTimeField("created_at", tbl.TableInfo)
 	tbl.DELETED_AT = NewTimeField("deleted_at", tbl.TableInfo)
@@ -720,8 +743,9 @@ type TABLE_USER_ROLES struct {
 // USER_ROLES creates an instance of the public.user_roles table.
 func USER_ROLES() TABLE_USER_ROLES {
 	tbl := TABLE_USER_ROLES{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "user_roles",
+		Schema:        "public",
+		Name:          "user_roles",
+		CaseSensitive: true,
 	}}
 	tbl.COHORT = NewStringField("cohort", tbl.TableInfo)
 	tbl.CREATED_AT = NewTimeField("created_at", tbl.TableInfo)
@@ -751,8 +775,9 @@ type TABLE_USER_ROLES_APPLICANTS struct {
 // USER_ROLES_APPLICANTS creates an instance of the public.user_roles_applicants table.
 func USER_ROLES_APPLICANTS() TABLE_USER_ROLES_APPLICANTS {
 	tbl := TABLE_USER_ROLES_APPLICANTS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "user_roles_applicants",
+		Schema:        "public",
+		Name:          "user_roles_applicants",
+		CaseSensitive: true,
 	}}
 	tbl.APPLICANT_DATA = NewJSONField("applicant_data", tbl.TableInfo)
 	tbl.APPLICANT_FORM_ID = NewNumberField("applicant_form_id", tbl.TableInfo)
@@ -778,8 +803,9 @@ type TABLE_USER_ROLES_STUDENTS struct {
 // USER_ROLES_STUDENTS creates an instance of the public.user_roles_students table.
 func USER_ROLES_STUDENTS() TABLE_USER_ROLES_STUDENTS {
 	tbl := TABLE_USER_ROLES_STUDENTS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "user_roles_students",
+		Schema:        "public",
+		Name:          "user_roles_students",
+		CaseSensitive: true,
 	}}
 	tbl.STUDENT_DATA = NewJSONField("student_data", tbl.TableInfo)
 	tbl.TEAM_ID = NewNumberField("team_id", tbl.TableInfo)
@@ -805,8 +831,9 @@ type TABLE_USERS struct {
 // USERS creates an instance of the public.users table.
 func USERS() TABLE_USERS {
 	tbl := TABLE_USERS{TableInfo: &TableInfo{
-		Schema: "public",
-		Name:   "users",
+		Schema:        "public",
+		Name:          "users",
+		CaseSensitive: true,
 	}}
 	tbl.DISPLAYNAME = NewStringField("displayname", tbl.TableInfo)
 	tbl.EMAIL = NewStringField("email", tbl.TableInfo)
@@ -854,8 +881,9 @@ type VIEW_V_APPLICATIONS struct {
 // V_APPLICATIONS creates an instance of the app.v_applications view.
 func V_APPLICATIONS() VIEW_V_APPLICATIONS {
 	tbl := VIEW_V_APPLICATIONS{TableInfo: &TableInfo{
-		Schema: "app",
-		Name:   "v_applications",
+		Schema:        "app",
+		Name:          "v_applications",
+		CaseSensitive: true,
 	}}
 	tbl.APPLICANT1_ANSWERS = NewJSONField("applicant1_answers", tbl.TableInfo)
 	tbl.APPLICANT1_DISPLAYNAME = NewStringField("applicant1_displayname", tbl.TableInfo)
@@ -913,8 +941,9 @@ type VIEW_V_SUBMISSIONS struct {
 // V_SUBMISSIONS creates an instance of the app.v_submissions view.
 func V_SUBMISSIONS() VIEW_V_SUBMISSIONS {
 	tbl := VIEW_V_SUBMISSIONS{TableInfo: &TableInfo{
-		Schema: "app",
-		Name:   "v_submissions",
+		Schema:        "app",
+		Name:          "v_submissions",
+		CaseSensitive: true,
 	}}
 	tbl.ANSWERS = NewJSONField("answers", tbl.TableInfo)
 	tbl.COHORT = NewStringField("cohort", tbl.TableInfo)
@@ -974,8 +1003,9 @@ type VIEW_V_TEAM_EVALUATIONS struct {
 // V_TEAM_EVALUATIONS creates an instance of the app.v_team_evaluations view.
 func V_TEAM_EVALUATIONS() VIEW_V_TEAM_EVALUATIONS {
 	tbl := VIEW_V_TEAM_EVALUATIONS{TableInfo: &TableInfo{
-		Schema: "app",
-		Name:   "v_team_evaluations",
+		Schema:        "app",
+		Name:          "v_team_evaluations",
+		CaseSensitive: true,
 	}}
 	tbl.COHORT = NewStringField("cohort", tbl.TableInfo)
 	tbl.EVALUATEE_PROJECT_LEVEL = NewStringField("evaluatee_project_level", tbl.TableInfo)
@@ -1042,8 +1072,9 @@ type VIEW_V_TEAMS struct {
 // V_TEAMS creates an instance of the app.v_teams view.
 func V_TEAMS() VIEW_V_TEAMS {
 	tbl := VIEW_V_TEAMS{TableInfo: &TableInfo{
-		Schema: "app",
-		Name:   "v_teams",
+		Schema:        "app",
+		Name:          "v_teams",
+		CaseSensitive: true,
 	}}
 	tbl.ADVISER_DISPLAYNAME = NewStringField("adviser_displayname", tbl.TableInfo)
 	tbl.ADVISER_EMAIL = NewStringField("adviser_email", tbl.TableInfo)
@@ -1090,8 +1121,9 @@ type VIEW_V_TEAMS_AND_STUDENTS struct {
 // V_TEAMS_AND_STUDENTS creates an instance of the app.v_teams_and_students view.
 func V_TEAMS_AND_STUDENTS() VIEW_V_TEAMS_AND_STUDENTS {
 	tbl := VIEW_V_TEAMS_AND_STUDENTS{TableInfo: &TableInfo{
-		Schema: "app",
-		Name:   "v_teams_and_students",
+		Schema:        "app",
+		Name:          "v_teams_and_students",
+		CaseSensitive: true,
 	}}
 	tbl.ADVISER_USER_ROLE_ID = NewNumberField("adviser_user_role_id", tbl.TableInfo)
 	tbl.MENTOR_USER_ROLE_ID = NewNumberField("mentor_user_role_id", tbl.TableInfo)
@@ -1145,8 +1177,9 @@ type VIEW_V_USER_EVALUATIONS struct {
 // V_USER_EVALUATIONS creates an instance of the app.v_user_evaluations view.
 func V_USER_EVALUATIONS() VIEW_V_USER_EVALUATIONS {
 	tbl := VIEW_V_USER_EVALUATIONS{TableInfo: &TableInfo{
-		Schema: "app",
-		Name:   "v_user_evaluations",
+		Schema:        "app",
+		Name:          "v_user_evaluations",
+		CaseSensitive: true,
 	}}
 	tbl.COHORT = NewStringField("cohort", tbl.TableInfo)
 	tbl.EVALUATEE_PROJECT_LEVEL = NewStringField("evaluatee_project_level", tbl.TableInfo)
@@ -0,0 +1,189 @@
+package sq
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// QueryError is the error returned by Fetch, Exec and Cursor when a query
+// fails to run or its results fail to be scanned. The underlying error (e.g. a
+// *pq.Error) can be retrieved with errors.As or errors.Unwrap.
+type QueryError struct {
+	Kind  string        // the kind of query e.g. SELECT, INSERT, UNION
+	Query string        // the SQL query
+	Args  []interface{} // the args of the SQL query
+	// Index is the index of the field (in the order the mapper added them)
+	// that failed to scan, or -1 if the error is not a scan error.
+	Index int
+	// Field is the field that failed to scan, or nil if the error is not a
+	// scan error.
+	Field Field
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *QueryError) Error() string {
+	if e.Field == nil {
+		return e.Kind + " query failed: " + e.Err.Error()
+	}
+	buf := &strings.Builder{}
+	var args []interface{}
+	e.Field.AppendSQLExclude(buf, &args, nil)
+	return e.Kind + " query failed to scan field " + strconv.Itoa(e.Index) + " (" +
+		DollarInterpolate(buf.String(), args...) + "), please check if your mapper function is correct: " +
+		e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// newQueryError wraps the error in a QueryError. If fields is not nil and the
+// error is a rowError, the QueryError reports the field that failed to scan. It returns
+// nil if err is nil, and sql.ErrNoRows as is so that it can still be compared
+// with ==.
+func newQueryError(kind, query string, args []interface{}, fields []Field, err error) error {
+	if err == nil || err == sql.ErrNoRows {
+		return err
+	}
+	qerr := &QueryError{Kind: kind, Query: query, Args: args, Index: -1, Err: err}
+	if fields == nil {
+		return qerr
+	}
+	var rerr *rowError
+	if errors.As(err, &rerr) && rerr.index >= 0 && rerr.index < len(fields) {
+		qerr.Index, qerr.Field, qerr.Err = rerr.index, fields[rerr.index], rerr.err
+	}
+	return qerr
+}
+
+// rowError is the error a Row panics with when the value of its index-th
+// field cannot be converted into the destination.
+type rowError struct {
+	index int
+	err   error
+}
+
+// Error implements the error interface.
+func (e *rowError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *rowError) Unwrap() error {
+	return e.err
+}
+
+// BuildError is an error in how a query was built e.g. a scalar subquery that
+// selects more than one field. AppendSQL cannot return an error, so it writes
+// the BuildError into the query as a bind parameter instead. Fetch, Exec and
+// Cursor return it (wrapped in a QueryError) without running the query. The
+// args returned by ToSQL can be checked with ArgsError, and running the query
+// anyway fails with the BuildError because it is a driver.Valuer that returns
+// itself as the error.
+type BuildError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e *BuildError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// Value implements the driver.Valuer interface. It always returns the
+// BuildError as the error.
+func (e *BuildError) Value() (driver.Value, error) {
+	return nil, e
+}
+
+// ArgsError returns the first *BuildError in the args of a query, or nil if
+// the query was built without errors.
+//
+//	query, args := q.ToSQL()
+//	if err := ArgsError(args); err != nil {
+//		return err
+//	}
+func ArgsError(args []interface{}) error {
+	for _, arg := range args {
+		if err, ok := arg.(*BuildError); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendBuildError writes the error into the buffer and args slice as a
+// *BuildError bind parameter.
+func appendBuildError(buf *strings.Builder, args *[]interface{}, err error) {
+	buf.WriteString("?")
+	*args = append(*args, &BuildError{Err: err})
+}
+
+// Postgres SQLSTATE codes, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	codeNotNullViolation     = "23502"
+	codeForeignKeyViolation  = "23503"
+	codeUniqueViolation      = "23505"
+	codeCheckViolation       = "23514"
+	codeSerializationFailure = "40001"
+	codeDeadlockDetected     = "40P01"
+)
+
+// pqError returns the *pq.Error in the error chain if it has the SQLSTATE
+// code, otherwise it returns nil.
+func pqError(err error, code pq.ErrorCode) *pq.Error {
+	var pqerr *pq.Error
+	if errors.As(err, &pqerr) && pqerr.Code == code {
+		return pqerr
+	}
+	return nil
+}
+
+// IsUniqueViolation reports whether the error is a unique constraint
+// violation. If constraint is not empty, the violated constraint must also
+// have that name.
+func IsUniqueViolation(err error, constraint string) bool {
+	pqerr := pqError(err, codeUniqueViolation)
+	return pqerr != nil && (constraint == "" || pqerr.Constraint == constraint)
+}
+
+// IsForeignKeyViolation reports whether the error is a foreign key constraint
+// violation.
+func IsForeignKeyViolation(err error) bool {
+	return pqError(err, codeForeignKeyViolation) != nil
+}
+
+// IsNotNullViolation reports whether the error is a NOT NULL constraint
+// violation.
+func IsNotNullViolation(err error) bool {
+	return pqError(err, codeNotNullViolation) != nil
+}
+
+// IsCheckViolation reports whether the error is a CHECK constraint violation.
+func IsCheckViolation(err error) bool {
+	return pqError(err, codeCheckViolation) != nil
+}
+
+// IsSerializationFailure reports whether the error is a serialization
+// failure, which means the transaction should be retried.
+func IsSerializationFailure(err error) bool {
+	return pqError(err, codeSerializationFailure) != nil
+}
+
+// IsDeadlock reports whether the error is due to a deadlock being detected,
+// which means the transaction should be retried.
+func IsDeadlock(err error) bool {
+	return pqError(err, codeDeadlockDetected) != nil
+}
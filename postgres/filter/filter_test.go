@@ -0,0 +1,144 @@
+package filter
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	sq "github.com/bokwoon95/go-structured-query/postgres"
+	"github.com/matryer/is"
+)
+
+func allowlist() (sq.SelectQuery, Allowlist) {
+	tbl := &sq.TableInfo{Name: "users"}
+	allowlist := Allowlist{
+		"name":       String(sq.NewStringField("name", tbl)),
+		"status":     Enum(sq.NewEnumField("status", tbl), "active", "suspended"),
+		"score":      Number(sq.NewNumberField("score", tbl)),
+		"created_at": Time(sq.NewTimeField("created_at", tbl)),
+		"verified":   Boolean(sq.NewBooleanField("verified", tbl)),
+	}
+	return sq.Select(sq.Fieldf("1")).From(tbl), allowlist
+}
+
+func TestBuild(t *testing.T) {
+	type TT struct {
+		description string
+		query       string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		{
+			description: "no filters",
+			query:       "",
+			wantQuery:   "SELECT 1 FROM users",
+		},
+		{
+			description: "eq without an operator",
+			query:       "status=active",
+			wantQuery:   "SELECT 1 FROM users WHERE users.status = $1",
+			wantArgs:    []interface{}{"active"},
+		},
+		{
+			description: "typed values",
+			query:       "created_at=gt:2024-01-01&score=le:9.5&verified=eq:true&name=like:bob%25",
+			wantQuery: "SELECT 1 FROM users WHERE users.created_at > $1 AND users.name LIKE $2" +
+				" AND users.score <= $3 AND users.verified = $4",
+			wantArgs: []interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "bob%", 9.5, true},
+		},
+		{
+			description: "RFC 3339 timestamp",
+			query:       "created_at=le:2024-01-01T10:00:00Z",
+			wantQuery:   "SELECT 1 FROM users WHERE users.created_at <= $1",
+			wantArgs:    []interface{}{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+		},
+		{
+			description: "in and null",
+			query:       "score=nin:1,2,3&status=in:active,suspended&name=null:false",
+			wantQuery: "SELECT 1 FROM users WHERE users.name IS NOT NULL" +
+				" AND users.score NOT IN ($1, $2, $3) AND users.status IN ($4, $5)",
+			wantArgs: []interface{}{int64(1), int64(2), int64(3), "active", "suspended"},
+		},
+		{
+			description: "range on the same field",
+			query:       "score=ge:1&score=lt:10",
+			wantQuery:   "SELECT 1 FROM users WHERE users.score >= $1 AND users.score < $2",
+			wantArgs:    []interface{}{int64(1), int64(10)},
+		},
+		{
+			description: "sort",
+			query:       "sort=-created_at,name&verified=false&cursor=abc",
+			wantQuery:   "SELECT 1 FROM users WHERE users.verified = $1 ORDER BY users.created_at DESC, users.name",
+			wantArgs:    []interface{}{false},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			q, allowlist := allowlist()
+			values, err := url.ParseQuery(tt.query)
+			is.NoErr(err)
+			spec, err := ParseQuery(values, "cursor")
+			is.NoErr(err)
+			predicate, orderBy, err := allowlist.Build(spec)
+			is.NoErr(err)
+			gotQuery, gotArgs := q.Where(predicate.Predicates...).OrderBy(orderBy...).ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestBuild_Error(t *testing.T) {
+	type TT struct {
+		description string
+		query       string
+		wantError   string
+	}
+	tests := []TT{
+		{"unknown field", "password=eq:hunter2", `invalid filter "password": unknown field`},
+		{"unknown sort field", "sort=password", `invalid sort "password": unknown field`},
+		{"empty sort field", "sort=name,", `invalid sort "": empty field name`},
+		{"duplicate sort field", "sort=name,-name", `invalid sort "name": sorted more than once`},
+		{
+			"unsupported operator", "status=gt:active",
+			`invalid filter "status": operator "gt" is not supported, expected one of eq, ne, in, nin, null`,
+		},
+		{"unknown enum value", "status=in:active,deleted", `invalid filter "status": "deleted" is not one of the allowed values`},
+		{
+			"unknown operator", "score=gte:5",
+			`invalid filter "score": unknown operator "gte", expected one of eq, ne, gt, ge, lt, le, in, nin, like, null`,
+		},
+		{"not a number", "score=1 OR 1=1", `invalid filter "score": "1 OR 1=1" is not a number`},
+		{"NaN", "score=NaN", `invalid filter "score": "NaN" is not a finite number`},
+		{"infinity", "score=gt:-Inf", `invalid filter "score": "-Inf" is not a finite number`},
+		{"infinity in a list", "score=in:1,infinity", `invalid filter "score": "infinity" is not a finite number`},
+		{
+			"not a time", "created_at=yesterday",
+			`invalid filter "created_at": "yesterday" is not an RFC 3339 timestamp or a date in the form 2006-01-02`,
+		},
+		{"not a bool", "verified=yes", `invalid filter "verified": "yes" is not true or false`},
+		{"null not a bool", "name=null:maybe", `invalid filter "name": null expects true or false, got "maybe"`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			_, allowlist := allowlist()
+			values, err := url.ParseQuery(tt.query)
+			is.NoErr(err)
+			spec, err := ParseQuery(values)
+			if err == nil {
+				_, _, err = allowlist.Build(spec)
+			}
+			var ferr *Error
+			is.True(errors.As(err, &ferr))
+			is.Equal(tt.wantError, err.Error())
+		})
+	}
+}
@@ -7,6 +7,10 @@ type FunctionInfo struct {
 	Name      string
 	Alias     string
 	Arguments []interface{}
+	// CaseSensitive marks the Schema, Name and result column names as the
+	// exact names in the database, so that they are quoted if they would
+	// otherwise not be read back as the same names. It is set by sqgen.
+	CaseSensitive bool
 }
 
 // AppendSQL adds the fully qualified function call into the buffer.
@@ -19,19 +23,18 @@ func (f *FunctionInfo) AppendSQLExclude(buf *strings.Builder, args *[]interface{
 	if f == nil {
 		return
 	}
-	var format string
+	name := &strings.Builder{}
 	if f.Schema != "" {
-		if strings.ContainsAny(f.Schema, " \t") {
-			format = `"` + f.Schema + `".`
-		} else {
-			format = f.Schema + "."
-		}
+		appendIdentifier(name, f.Schema, f.CaseSensitive)
+		name.WriteString(".")
 	}
+	appendIdentifier(name, f.Name, f.CaseSensitive)
+	format := name.String()
 	switch len(f.Arguments) {
 	case 0:
-		format = format + f.Name + "()"
+		format = format + "()"
 	default:
-		format = format + f.Name + "(?" + strings.Repeat(", ?", len(f.Arguments)-1) + ")"
+		format = format + "(?" + strings.Repeat(", ?", len(f.Arguments)-1) + ")"
 	}
 	ExpandValues(buf, args, excludedTableQualifiers, format, f.Arguments)
 }
@@ -54,3 +57,8 @@ func (f *FunctionInfo) GetAlias() string {
 func (f *FunctionInfo) GetName() string {
 	return f.Name
 }
+
+// isCaseSensitive reports whether the FunctionInfo names are case sensitive.
+func (f *FunctionInfo) isCaseSensitive() bool {
+	return f != nil && f.CaseSensitive
+}
@@ -0,0 +1,118 @@
+package sq
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GroupingElementType is the type of a GroupingElement.
+type GroupingElementType string
+
+// Possible GroupingElementTypes
+const (
+	GroupingTypeRollup GroupingElementType = "ROLLUP"
+	GroupingTypeCube   GroupingElementType = "CUBE"
+	GroupingTypeSets   GroupingElementType = "GROUPING SETS"
+)
+
+// GroupingElement represents a ROLLUP, CUBE or GROUPING SETS element in the
+// GROUP BY clause. It is created with the Rollup, Cube and GroupingSets
+// functions and passed to SelectQuery.GroupBy like any other Field.
+type GroupingElement struct {
+	Type GroupingElementType
+	Sets []Fields
+}
+
+// AppendSQLExclude marshals the GroupingElement into a buffer and an args
+// slice. It propagates the excludedTableQualifiers down to its child elements.
+// A GroupingElement without any fields (or sets, for GROUPING SETS) is written
+// as a BuildError.
+func (g GroupingElement) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
+	if len(g.Sets) == 0 {
+		appendBuildError(buf, args, fmt.Errorf("%s needs at least one element", g.Type))
+		return
+	}
+	buf.WriteString(string(g.Type))
+	buf.WriteString("(")
+	for i, set := range g.Sets {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		// A set containing exactly one field does not need to be bracketed,
+		// which keeps ROLLUP(a, b) from becoming ROLLUP((a), (b))
+		if len(set) == 1 {
+			set.AppendSQLExclude(buf, args, excludedTableQualifiers)
+			continue
+		}
+		buf.WriteString("(")
+		set.AppendSQLExclude(buf, args, excludedTableQualifiers)
+		buf.WriteString(")")
+	}
+	buf.WriteString(")")
+}
+
+// Rollup returns a 'ROLLUP(fields...)' GroupingElement, which groups by each
+// prefix of the fields i.e. (a, b, c), (a, b), (a) and ().
+func Rollup(fields ...Field) GroupingElement {
+	sets := make([]Fields, len(fields))
+	for i, field := range fields {
+		sets[i] = Fields{field}
+	}
+	return GroupingElement{
+		Type: GroupingTypeRollup,
+		Sets: sets,
+	}
+}
+
+// Cube returns a 'CUBE(fields...)' GroupingElement, which groups by every
+// subset of the fields.
+func Cube(fields ...Field) GroupingElement {
+	sets := make([]Fields, len(fields))
+	for i, field := range fields {
+		sets[i] = Fields{field}
+	}
+	return GroupingElement{
+		Type: GroupingTypeCube,
+		Sets: sets,
+	}
+}
+
+// GroupingSets returns a 'GROUPING SETS(sets...)' GroupingElement, which
+// groups by each of the sets separately. An empty set groups all rows into a
+// single grand total row.
+//
+//	GroupingSets(Fields{a, b}, Fields{a}, Fields{})
+//	// GROUPING SETS((a, b), a, ())
+func GroupingSets(sets ...Fields) GroupingElement {
+	return GroupingElement{
+		Type: GroupingTypeSets,
+		Sets: sets,
+	}
+}
+
+// GetAlias implements the Field interface. It always returns an empty string
+// because GroupingElements do not have aliases.
+func (g GroupingElement) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Field interface. It returns the type of the
+// GroupingElement.
+func (g GroupingElement) GetName() string {
+	return string(g.Type)
+}
+
+// Grouping represents the GROUPING(fields...) function, which returns a bit
+// mask indicating which of the fields are not part of the grouping set of the
+// current row. It is used to tell subtotal rows apart from regular rows.
+func Grouping(fields ...Field) NumberField {
+	if len(fields) == 0 {
+		return numberFunction("GROUPING(?)", &BuildError{Err: errors.New("GROUPING needs at least one field")})
+	}
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		values[i] = field
+	}
+	return numberFunction("GROUPING(?"+strings.Repeat(", ?", len(fields)-1)+")", values...)
+}
@@ -0,0 +1,84 @@
+package sq
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGroupingElement(t *testing.T) {
+	type TT struct {
+		description string
+		q           SelectQuery
+		wantQuery   string
+		wantErr     string
+	}
+	ur := USER_ROLES().As("ur")
+	base := From(ur).Select(ur.ROLE, ur.COHORT, Grouping(ur.ROLE, ur.COHORT), Count())
+	tests := []TT{
+		{
+			"Rollup",
+			base.GroupBy(Rollup(ur.ROLE, ur.COHORT)),
+			"SELECT ur.role, ur.cohort, GROUPING(ur.role, ur.cohort), COUNT(*) FROM public.user_roles AS ur" +
+				" GROUP BY ROLLUP(ur.role, ur.cohort)",
+			"",
+		},
+		{
+			"Cube",
+			base.GroupBy(ur.USER_ID, Cube(ur.ROLE, ur.COHORT)),
+			"SELECT ur.role, ur.cohort, GROUPING(ur.role, ur.cohort), COUNT(*) FROM public.user_roles AS ur" +
+				" GROUP BY ur.user_id, CUBE(ur.role, ur.cohort)",
+			"",
+		},
+		{
+			"GroupingSets",
+			base.GroupBy(GroupingSets(Fields{ur.ROLE, ur.COHORT}, Fields{ur.ROLE}, Fields{})),
+			"SELECT ur.role, ur.cohort, GROUPING(ur.role, ur.cohort), COUNT(*) FROM public.user_roles AS ur" +
+				" GROUP BY GROUPING SETS((ur.role, ur.cohort), ur.role, ())",
+			"",
+		},
+		{
+			"empty Rollup",
+			base.GroupBy(ur.USER_ID, Rollup()),
+			"SELECT ur.role, ur.cohort, GROUPING(ur.role, ur.cohort), COUNT(*) FROM public.user_roles AS ur" +
+				" GROUP BY ur.user_id, $1",
+			"ROLLUP needs at least one element",
+		},
+		{
+			"empty Cube",
+			base.GroupBy(Cube()),
+			"SELECT ur.role, ur.cohort, GROUPING(ur.role, ur.cohort), COUNT(*) FROM public.user_roles AS ur" +
+				" GROUP BY $1",
+			"CUBE needs at least one element",
+		},
+		{
+			"empty GroupingSets",
+			base.GroupBy(GroupingSets()),
+			"SELECT ur.role, ur.cohort, GROUPING(ur.role, ur.cohort), COUNT(*) FROM public.user_roles AS ur" +
+				" GROUP BY $1",
+			"GROUPING SETS needs at least one element",
+		},
+		{
+			"empty Grouping",
+			From(ur).Select(Grouping()).GroupBy(Rollup(ur.ROLE)),
+			"SELECT GROUPING($1) FROM public.user_roles AS ur GROUP BY ROLLUP(ur.role)",
+			"GROUPING needs at least one field",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			if tt.wantErr == "" {
+				is.Equal(0, len(gotArgs))
+				return
+			}
+			err := ArgsError(gotArgs)
+			is.True(err != nil)
+			is.Equal(tt.wantErr, err.Error())
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package sq
+
+import "strings"
+
+// reservedWords are the Postgres key words that are reserved, including those
+// that can be function or type names. They cannot be used as an identifier
+// unless they are quoted.
+var reservedWords = map[string]bool{
+	"all": true, "analyse": true, "analyze": true, "and": true, "any": true,
+	"array": true, "as": true, "asc": true, "asymmetric": true,
+	"authorization": true, "binary": true, "both": true, "case": true,
+	"cast": true, "check": true, "collate": true, "collation": true,
+	"column": true, "concurrently": true, "constraint": true, "create": true,
+	"cross": true, "current_catalog": true, "current_date": true,
+	"current_role": true, "current_schema": true, "current_time": true,
+	"current_timestamp": true, "current_user": true, "default": true,
+	"deferrable": true, "desc": true, "distinct": true, "do": true,
+	"else": true, "end": true, "except": true, "false": true, "fetch": true,
+	"for": true, "foreign": true, "freeze": true, "from": true, "full": true,
+	"grant": true, "group": true, "having": true, "ilike": true, "in": true,
+	"initially": true, "inner": true, "intersect": true, "into": true,
+	"is": true, "isnull": true, "join": true, "lateral": true, "leading": true,
+	"left": true, "like": true, "limit": true, "localtime": true,
+	"localtimestamp": true, "natural": true, "not": true, "notnull": true,
+	"null": true, "offset": true, "on": true, "only": true, "or": true,
+	"order": true, "outer": true, "overlaps": true, "placing": true,
+	"primary": true, "references": true, "returning": true, "right": true,
+	"select": true, "session_user": true, "similar": true, "some": true,
+	"symmetric": true, "system_user": true, "table": true, "tablesample": true,
+	"then": true, "to": true, "trailing": true, "true": true, "union": true,
+	"unique": true, "user": true, "using": true, "variadic": true,
+	"verbose": true, "when": true, "where": true, "window": true, "with": true,
+}
+
+// QuoteIdentifier returns the name as a Postgres identifier, double quoted if
+// it has to be quoted to be read back as exactly the same name i.e. if it is a
+// reserved word, or if it has upper case letters or any other character that
+// is not allowed in an unquoted identifier. Embedded double quotes are
+// escaped by doubling them.
+func QuoteIdentifier(name string) string {
+	buf := &strings.Builder{}
+	appendIdentifier(buf, name, true)
+	return buf.String()
+}
+
+// appendIdentifier writes the identifier into the buffer, double quoting it
+// if needed.
+//
+// Reserved words and names with whitespace or double quotes are always
+// quoted. Postgres folds unquoted identifiers to lower case, so names that are
+// case sensitive (i.e. the exact name in the database, like the ones generated
+// by sqgen) are also quoted if they have upper case letters or other
+// characters that are not allowed in an unquoted identifier. Names that are
+// not case sensitive are otherwise written as is, so that they are folded the
+// same way as in the SQL that created them.
+func appendIdentifier(buf *strings.Builder, name string, caseSensitive bool) {
+	if !identifierNeedsQuotes(name, caseSensitive) {
+		buf.WriteString(name)
+		return
+	}
+	buf.WriteString(`"`)
+	buf.WriteString(strings.ReplaceAll(name, `"`, `""`))
+	buf.WriteString(`"`)
+}
+
+// identifierNeedsQuotes reports whether the identifier has to be quoted. See
+// appendIdentifier.
+func identifierNeedsQuotes(name string, caseSensitive bool) bool {
+	if name == "" {
+		return false
+	}
+	if !caseSensitive && len(name) > 1 && name[0] == '"' && name[len(name)-1] == '"' {
+		// already quoted by the user
+		return false
+	}
+	if reservedWords[strings.ToLower(name)] {
+		return true
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '"' || c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			return true
+		case !caseSensitive:
+			continue
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 0x80:
+			continue
+		case i > 0 && (c >= '0' && c <= '9' || c == '$'):
+			continue
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// qualify returns the column qualified with the table qualifier i.e.
+// 'qualifier.column'. The qualifier is quoted if needed (see appendIdentifier),
+// the column is written as is.
+func qualify(qualifier, column string) string {
+	buf := &strings.Builder{}
+	appendIdentifier(buf, qualifier, false)
+	buf.WriteString(".")
+	buf.WriteString(column)
+	return buf.String()
+}
+
+// appendIdentifiers writes the comma separated list of identifiers into the
+// buffer.
+func appendIdentifiers(buf *strings.Builder, names []string) {
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		appendIdentifier(buf, name, false)
+	}
+}
+
+// appendColumn writes the column into the buffer, qualified with the alias
+// or name of its table unless the table qualifier is excluded. The table and
+// column names are case sensitive if the table is.
+func appendColumn(buf *strings.Builder, table Table, name string, excludedTableQualifiers []string) {
+	caseSensitive := isCaseSensitive(table)
+	tableQualifier := table.GetAlias()
+	qualifierCaseSensitive := false
+	if tableQualifier == "" {
+		tableQualifier = table.GetName()
+		qualifierCaseSensitive = caseSensitive
+	}
+	for _, excludedTableQualifier := range excludedTableQualifiers {
+		if tableQualifier == excludedTableQualifier {
+			tableQualifier = ""
+			break
+		}
+	}
+	if tableQualifier != "" {
+		appendIdentifier(buf, tableQualifier, qualifierCaseSensitive)
+		buf.WriteString(".")
+	}
+	appendIdentifier(buf, name, caseSensitive)
+}
+
+// isCaseSensitive reports whether the names of the table and its columns are
+// case sensitive.
+func isCaseSensitive(table Table) bool {
+	t, ok := table.(interface{ isCaseSensitive() bool })
+	return ok && t.isCaseSensitive()
+}
@@ -0,0 +1,92 @@
+package sq
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	type TT struct {
+		description string
+		name        string
+		want        string
+	}
+	tests := []TT{
+		{"empty", "", ""},
+		{"plain", "user_id", "user_id"},
+		{"reserved word", "user", `"user"`},
+		{"reserved word upper case", "ORDER", `"ORDER"`},
+		{"non-reserved key word", "name", "name"},
+		{"mixed case", "createdAt", `"createdAt"`},
+		{"starts with a digit", "1st", `"1st"`},
+		{"dollar", "a$b", "a$b"},
+		{"whitespace", "first name", `"first name"`},
+		{"embedded quote", `say "hi"`, `"say ""hi"""`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			is.Equal(tt.want, QuoteIdentifier(tt.name))
+		})
+	}
+}
+
+func TestIdentifiers(t *testing.T) {
+	type TT struct {
+		description string
+		q           Query
+		wantQuery   string
+	}
+	tbl := &TableInfo{Schema: "public", Name: "Order", CaseSensitive: true}
+	createdAt := NewTimeField("createdAt", tbl)
+	user := NewStringField("user", tbl)
+	tests := []TT{
+		{
+			"case sensitive columns",
+			Select(createdAt, user).From(tbl),
+			`SELECT "Order"."createdAt", "Order"."user" FROM public."Order"`,
+		},
+		{
+			"unflagged columns",
+			Select(NewTimeField("createdAt", &TableInfo{Name: "orders"}), NewStringField("order", &TableInfo{Name: "orders"})),
+			`SELECT orders.createdAt, orders."order"`,
+		},
+		{
+			"aliases",
+			Select(createdAt.As("end")).From(&TableInfo{Schema: "public", Name: "Order", Alias: "user", CaseSensitive: true}),
+			`SELECT "Order"."createdAt" AS "end" FROM public."Order" AS "user"`,
+		},
+		{
+			"CTE",
+			Select(Int(1)).With(NewCTE("select", Select(Int(1)), "from", "to")).From(NewCTE("select", nil)),
+			`WITH "select" ("from", "to") AS (SELECT $1) SELECT $2 FROM "select"`,
+		},
+		{
+			"window",
+			Select(Fieldf("SUM(?) OVER ?", user, PartitionBy(user).As("window").Name())).From(tbl).Window(PartitionBy(user).As("window")),
+			`SELECT SUM("Order"."user") OVER "window" FROM public."Order" WINDOW "window" AS (PARTITION BY "Order"."user")`,
+		},
+		{
+			"derived table qualifiers",
+			Select(NewCTE("select", nil).Get("id"), NewCTE("x", nil).As("user").Get("id"), Select(user).From(tbl).As("order").Get(`"user"`)),
+			`SELECT "select".id, "user".id, "order"."user"`,
+		},
+		{
+			"function",
+			Select(Fieldf("?", &FunctionInfo{Schema: "public", Name: "getUsers", CaseSensitive: true})),
+			`SELECT public."getUsers"()`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, _ := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+		})
+	}
+}
@@ -116,6 +116,27 @@ func TestInsertQuery_ToSQL(t *testing.T) {
 	}
 }
 
+func TestInsertQuery_Inserted(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	r := &Row{}
+	func(row *Row) {
+		_ = row.Int(u.USER_ID)
+		_ = row.Inserted()
+	}(r)
+	gotQuery, gotArgs := InsertInto(u).
+		Columns(u.EMAIL).
+		Values("aaa@email.com").
+		OnConflict(u.EMAIL).
+		DoUpdateSet(u.EMAIL.Set(Excluded(u.EMAIL))).
+		Returning(r.fields...).
+		ToSQL()
+	is.Equal("INSERT INTO public.users AS u (email) VALUES ($1)"+
+		" ON CONFLICT (email) DO UPDATE SET email = EXCLUDED.email"+
+		" RETURNING u.user_id, (xmax = 0)", gotQuery)
+	is.Equal([]interface{}{"aaa@email.com"}, gotArgs)
+}
+
 func TestInsertQuery_Fetch(t *testing.T) {
 	if testing.Short() {
 		return
@@ -328,6 +349,35 @@ func TestInsertQuery_Fetch(t *testing.T) {
 	tempDB.Close()
 }
 
+func TestInsertQuery_Cursor(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+	is := is.New(t)
+	db, err := sql.Open("txdb", "InsertQuery_Cursor")
+	is.NoErr(err)
+	defer db.Close()
+	u := USERS()
+
+	var emails []string
+	var email string
+	cur, err := WithDefaultLog(Lverbose).
+		InsertInto(u).
+		Columns(u.DISPLAYNAME, u.EMAIL).
+		Values("aaa", "aaa@email.com").
+		Values("bbb", "bbb@email.com").
+		Cursor(nil, db, func(row *Row) {
+			email = row.String(u.EMAIL)
+		})
+	is.NoErr(err)
+	defer cur.Close()
+	for cur.Next() {
+		emails = append(emails, email)
+	}
+	is.NoErr(cur.Err())
+	is.Equal([]string{"aaa@email.com", "bbb@email.com"}, emails)
+}
+
 func TestInsertQuery_Exec(t *testing.T) {
 	if testing.Short() {
 		return
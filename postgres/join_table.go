@@ -77,23 +77,7 @@ func (join JoinTable) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 		join.JoinType = JoinTypeInner
 	}
 	buf.WriteString(string(join.JoinType) + " ")
-	switch v := join.Table.(type) {
-	case nil:
-		buf.WriteString("NULL")
-	case Query:
-		buf.WriteString("(")
-		v.NestThis().AppendSQL(buf, args)
-		buf.WriteString(")")
-	default:
-		join.Table.AppendSQL(buf, args)
-	}
-	if join.Table != nil {
-		alias := join.Table.GetAlias()
-		if alias != "" {
-			buf.WriteString(" AS ")
-			buf.WriteString(alias)
-		}
-	}
+	appendTable(buf, args, join.Table)
 	if len(join.OnPredicates.Predicates) > 0 {
 		buf.WriteString(" ON ")
 		join.OnPredicates.Toplevel = true
@@ -115,3 +99,47 @@ func (joins JoinTables) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 		join.AppendSQL(buf, args)
 	}
 }
+
+// appendTable writes a table of a FROM, JOIN or USING clause into the buffer
+// and args, followed by its alias. Subqueries are bracketed and always
+// aliased, because every derived table must have an alias.
+func appendTable(buf *strings.Builder, args *[]interface{}, table Table) {
+	var alias string
+	switch v := table.(type) {
+	case nil:
+		buf.WriteString("NULL")
+		return
+	case Query:
+		buf.WriteString("(")
+		start, argStart := buf.Len(), len(*args)
+		v.NestThis().AppendSQL(buf, args)
+		// the alias is derived from the SQL that was just written, instead of
+		// rendering the query all over again
+		if alias = v.GetAlias(); alias == "" {
+			alias = hashName("subquery_", buf.String()[start:], (*args)[argStart:])
+		}
+		buf.WriteString(")")
+	default:
+		table.AppendSQL(buf, args)
+		alias = table.GetAlias()
+	}
+	if alias != "" {
+		buf.WriteString(" AS ")
+		appendIdentifier(buf, alias, false)
+	}
+}
+
+// derivedAlias returns the alias of a query used as a derived table. A query
+// without an alias is given one that is derived from its SQL and values, so
+// that the same query always gets the same alias and queries that differ only
+// in their values get different aliases. Use As to give it a readable alias,
+// or to join the very same query more than once.
+func derivedAlias(query Query) string {
+	if alias := query.GetAlias(); alias != "" {
+		return alias
+	}
+	buf := &strings.Builder{}
+	var args []interface{}
+	query.NestThis().AppendSQL(buf, &args)
+	return hashName("subquery_", buf.String(), args)
+}
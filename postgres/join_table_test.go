@@ -53,7 +53,7 @@ func TestJoinTable_AppendSQL(t *testing.T) {
 			desc := "join query (alias automatically added)"
 			u := USERS().As("u")
 			q := Select(u.USER_ID, u.DISPLAYNAME, u.EMAIL).From(u)
-			alias := q.GetAlias()
+			alias := derivedAlias(q)
 			is := is.New(t)
 			is.True(alias != "")
 			j := CustomJoin(JoinTypeInner, q, q.Get("user_id").Eq(1), q.Get("displayname").Eq("John"))
@@ -78,6 +78,23 @@ func TestJoinTable_AppendSQL(t *testing.T) {
 	}
 }
 
+func TestJoinTable_DerivedAliases(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	s1 := Select(u.USER_ID).From(u).Where(u.USER_ID.GtInt(1))
+	s2 := Select(u.USER_ID).From(u).Where(u.USER_ID.GtInt(2))
+	alias1, alias2 := derivedAlias(s1), derivedAlias(s2)
+	is.True(alias1 != alias2) // subqueries that differ only in their values get different aliases
+	q := From(s1).Join(s2, s1.Get("user_id").Eq(s2.Get("user_id"))).Select(s1.Get("user_id"))
+	gotQuery, gotArgs := q.ToSQL()
+	wantQuery := fmt.Sprintf("SELECT %[1]s.user_id"+
+		" FROM (SELECT u.user_id FROM public.users AS u WHERE u.user_id > $1) AS %[1]s"+
+		" JOIN (SELECT u.user_id FROM public.users AS u WHERE u.user_id > $2) AS %[2]s"+
+		" ON %[1]s.user_id = %[2]s.user_id", alias1, alias2)
+	is.Equal(wantQuery, gotQuery)
+	is.Equal([]interface{}{1, 2}, gotArgs)
+}
+
 func TestJoinTables_AppendSQL(t *testing.T) {
 	type TT struct {
 		description string
@@ -6,15 +6,19 @@ import (
 	"strings"
 )
 
-// JSONField either represents a JSON column or a literal value that can be
-// marshalled into a JSON string.
+// JSONField either represents a JSON column, a JSON expression or a literal
+// value that can be marshalled into a JSON string.
 type JSONField struct {
 	// JSONField will be one of the following:
 
-	// 1) Literal JSONable value (almost all structs can be converted to JSON)
+	// 1) JSON expression
+	format *string
+	values []interface{}
+
+	// 2) Literal JSONable value (almost all structs can be converted to JSON)
 	value interface{}
 
-	// 2) JSON column
+	// 3) JSON column
 	alias      string
 	table      Table
 	name       string
@@ -26,39 +30,16 @@ type JSONField struct {
 // JSONField internal struct comments.
 func (f JSONField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
 	switch {
+	case f.format != nil:
+		// 1) JSON expression
+		ExpandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
 	case f.value != nil:
-		// 1) Literal JSONable value
+		// 2) Literal JSONable value
 		buf.WriteString("?")
 		*args = append(*args, f.value)
 	default:
-		// 2) JSON column
-		tableQualifier := f.table.GetAlias()
-		if tableQualifier == "" {
-			tableQualifier = f.table.GetName()
-		}
-		for _, excludedTableQualifier := range excludedTableQualifiers {
-			if tableQualifier == excludedTableQualifier {
-				tableQualifier = ""
-				break
-			}
-		}
-		if tableQualifier != "" {
-			if strings.ContainsAny(tableQualifier, " \t") {
-				buf.WriteString(`"`)
-				buf.WriteString(tableQualifier)
-				buf.WriteString(`".`)
-			} else {
-				buf.WriteString(tableQualifier)
-				buf.WriteString(".")
-			}
-		}
-		if strings.ContainsAny(f.name, " \t") {
-			buf.WriteString(`"`)
-			buf.WriteString(f.name)
-			buf.WriteString(`"`)
-		} else {
-			buf.WriteString(f.name)
-		}
+		// 3) JSON column
+		appendColumn(buf, f.table, f.name, excludedTableQualifiers)
 	}
 	if f.descending != nil {
 		if *f.descending {
@@ -0,0 +1,188 @@
+package sq
+
+import "strings"
+
+// This file contains the lexer that finds the question mark ? placeholders in
+// a format string or query. A ? is only a placeholder if it appears outside of
+// string literals, quoted identifiers, dollar-quoted strings and comments.
+//
+// Postgres also uses ? in the jsonb operators ?, ?| and ?&. The ?| and ?&
+// operators are recognised by the lexer, but a lone ? operator cannot be told
+// apart from a placeholder so it must be escaped as ?? e.g.
+// Predicatef("data ?? ?", "key") is written as 'data ? $1'. The ?? escape is
+// kept as is when the format string is expanded, and is only unescaped to a
+// single ? when the query is rebound by QuestionToDollarPlaceholders.
+
+// nextPlaceholder returns the index of the next ? placeholder in the query. It
+// reports whether the ? is the start of an escaped ?? instead of a
+// placeholder. If there are no more placeholders, it returns -1.
+func nextPlaceholder(query string) (index int, escaped bool) {
+	for i := 0; i < len(query); i++ {
+		if query[i] != '?' {
+			i = skipLiteral(query, i)
+			continue
+		}
+		if i+1 < len(query) {
+			switch query[i+1] {
+			case '?':
+				return i, true
+			case '|', '&':
+				// the jsonb ?| and ?& operators, unless it is a placeholder
+				// followed by the || or && operator
+				if i+2 >= len(query) || query[i+2] != query[i+1] {
+					i++
+					continue
+				}
+			}
+		}
+		return i, false
+	}
+	return -1, false
+}
+
+// nextDollarPlaceholder returns the index and length of the next $1, $2, $3
+// etc placeholder in the query. If there are no more placeholders, it returns
+// -1.
+func nextDollarPlaceholder(query string) (index int, length int) {
+	for i := 0; i < len(query); i++ {
+		if query[i] == '$' && (i == 0 || !isIdentifierChar(query[i-1])) {
+			j := i + 1
+			for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+				j++
+			}
+			if j > i+1 {
+				return i, j - i
+			}
+		}
+		i = skipLiteral(query, i)
+	}
+	return -1, 0
+}
+
+// skipLiteral returns the index of the last byte of the string literal,
+// quoted identifier, dollar-quoted string or comment starting at query[i]. If
+// there is none starting at query[i], it returns i.
+func skipLiteral(query string, i int) int {
+	switch query[i] {
+	case '\'':
+		// E'...' strings allow backslash escapes
+		backslash := i > 0 && (query[i-1] == 'E' || query[i-1] == 'e') && (i < 2 || !isIdentifierChar(query[i-2]))
+		return skipQuoted(query, i, backslash)
+	case '"':
+		return skipQuoted(query, i, false)
+	case '-':
+		if strings.HasPrefix(query[i:], "--") {
+			return skipLineComment(query, i)
+		}
+	case '/':
+		if strings.HasPrefix(query[i:], "/*") {
+			return skipBlockComment(query, i)
+		}
+	case '$':
+		if i > 0 && isIdentifierChar(query[i-1]) {
+			return i
+		}
+		if tag := dollarQuoteTag(query[i:]); tag != "" {
+			end := strings.Index(query[i+len(tag):], tag)
+			if end < 0 {
+				return len(query) - 1
+			}
+			return i + len(tag) + end + len(tag) - 1
+		}
+	}
+	return i
+}
+
+// countPlaceholders returns the number of ? placeholders in the query.
+func countPlaceholders(query string) int {
+	var count int
+	for {
+		i, escaped := nextPlaceholder(query)
+		if i < 0 {
+			return count
+		}
+		if escaped {
+			query = query[i+2:]
+			continue
+		}
+		count++
+		query = query[i+1:]
+	}
+}
+
+// skipQuoted returns the index of the quote that closes the quoted string or
+// identifier starting at query[start]. A doubled quote is an escaped quote,
+// and so is a quote preceded by a backslash if backslash is true. If the quote
+// is never closed, it returns the index of the last byte of the query.
+func skipQuoted(query string, start int, backslash bool) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return len(query) - 1
+}
+
+// skipLineComment returns the index of the newline that ends the -- comment
+// starting at query[start].
+func skipLineComment(query string, start int) int {
+	if end := strings.IndexByte(query[start:], '\n'); end >= 0 {
+		return start + end
+	}
+	return len(query) - 1
+}
+
+// skipBlockComment returns the index of the last byte of the /* */ comment
+// starting at query[start]. Postgres block comments can be nested.
+func skipBlockComment(query string, start int) int {
+	depth := 0
+	for i := start; i+1 < len(query); i++ {
+		switch query[i : i+2] {
+		case "/*":
+			depth++
+			i++
+		case "*/":
+			depth--
+			i++
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(query) - 1
+}
+
+// dollarQuoteTag returns the $tag$ that starts the query, or an empty string
+// if the query does not start with a dollar quote. $1 is a positional
+// parameter and not a dollar quote.
+func dollarQuoteTag(query string) string {
+	for i := 1; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '$':
+			return query[:i+1]
+		case c >= '0' && c <= '9':
+			if i == 1 {
+				return ""
+			}
+		case !isIdentifierChar(c):
+			return ""
+		}
+	}
+	return ""
+}
+
+// isIdentifierChar reports whether c can be part of an unquoted identifier.
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
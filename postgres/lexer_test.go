@@ -0,0 +1,162 @@
+package sq
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestExpandValues(t *testing.T) {
+	type TT struct {
+		description string
+		format      string
+		values      []interface{}
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		{
+			"placeholders",
+			"? = ?",
+			[]interface{}{1, "a"},
+			"? = ?",
+			[]interface{}{1, "a"},
+		},
+		{
+			"string literals",
+			"x = 'what?' AND y = 'it''s ?' AND z = ?",
+			[]interface{}{1},
+			"x = 'what?' AND y = 'it''s ?' AND z = ?",
+			[]interface{}{1},
+		},
+		{
+			"escape string literal",
+			`x = E'\'?' AND y = ?`,
+			[]interface{}{1},
+			`x = E'\'?' AND y = ?`,
+			[]interface{}{1},
+		},
+		{
+			"quoted identifier",
+			`"what?" = ?`,
+			[]interface{}{1},
+			`"what?" = ?`,
+			[]interface{}{1},
+		},
+		{
+			"dollar-quoted strings",
+			"$$?$$ || $tag$ $$ ? $tag$ || ?",
+			[]interface{}{1},
+			"$$?$$ || $tag$ $$ ? $tag$ || ?",
+			[]interface{}{1},
+		},
+		{
+			"positional parameter is not a dollar quote",
+			"$1 = ? AND $2 = ?",
+			[]interface{}{1, 2},
+			"$1 = ? AND $2 = ?",
+			[]interface{}{1, 2},
+		},
+		{
+			"comments",
+			"? -- what?\n/* what? /* nested? */ ? */ + ?",
+			[]interface{}{1, 2},
+			"? -- what?\n/* what? /* nested? */ ? */ + ?",
+			[]interface{}{1, 2},
+		},
+		{
+			"jsonb operators",
+			"data ?| ? AND data ?& ? AND data ?? ?",
+			[]interface{}{"a", "b", "c"},
+			"data ?| ? AND data ?& ? AND data ?? ?",
+			[]interface{}{"a", "b", "c"},
+		},
+		{
+			"placeholder followed by || and &&",
+			"?||? AND ?&&?",
+			[]interface{}{1, 2, 3, 4},
+			"?||? AND ?&&?",
+			[]interface{}{1, 2, 3, 4},
+		},
+		{
+			"too few values",
+			"? = ?",
+			[]interface{}{1},
+			"?",
+			[]interface{}{&BuildError{Err: errors.New(`format "? = ?" has 2 placeholders, but 1 values were given`)}},
+		},
+		{
+			"too many values",
+			"x = '?'",
+			[]interface{}{1},
+			"?",
+			[]interface{}{&BuildError{Err: errors.New(`format "x = '?'" has 0 placeholders, but 1 values were given`)}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			ExpandValues(buf, &args, nil, tt.format, tt.values)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestQuestionToDollarPlaceholders(t *testing.T) {
+	type TT struct {
+		description string
+		query       string
+		wantQuery   string
+	}
+	tests := []TT{
+		{
+			"placeholders",
+			"SELECT ? FROM t WHERE x = ? AND y = ?",
+			"SELECT $1 FROM t WHERE x = $2 AND y = $3",
+		},
+		{
+			"literals and comments",
+			`SELECT '?', "?", $$?$$ /* ? */ FROM t WHERE x = ? -- ?`,
+			`SELECT '?', "?", $$?$$ /* ? */ FROM t WHERE x = $1 -- ?`,
+		},
+		{
+			"jsonb operators",
+			"SELECT 1 FROM t WHERE data ?? ? AND data ?| ? AND data ?& ?",
+			"SELECT 1 FROM t WHERE data ? $1 AND data ?| $2 AND data ?& $3",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			QuestionToDollarPlaceholders(buf, tt.query)
+			is.Equal(tt.wantQuery, buf.String())
+		})
+	}
+}
+
+func TestQuestionInterpolate(t *testing.T) {
+	is := is.New(t)
+	is.Equal(
+		"SELECT 'a' WHERE data ? 'key' AND x = 'what?'",
+		QuestionInterpolate("SELECT ? WHERE data ?? ? AND x = 'what?'", "a", "key"),
+	)
+}
+
+func TestPredicatef_jsonb(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	q := Select(u.USER_ID).From(u).Where(Predicatef("? ?? ?", Fieldf("u.data"), "key"))
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal("SELECT u.user_id FROM public.users AS u WHERE u.data ? $1", gotQuery)
+	is.Equal([]interface{}{"key"}, gotArgs)
+}
@@ -0,0 +1,410 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MergeAction represents the action of a WHEN clause in a MERGE query.
+type MergeAction string
+
+// MergeActions
+const (
+	MergeActionUpdate    MergeAction = "UPDATE"
+	MergeActionDelete    MergeAction = "DELETE"
+	MergeActionInsert    MergeAction = "INSERT"
+	MergeActionDoNothing MergeAction = "DO NOTHING"
+)
+
+// MergeWhen represents a 'WHEN [NOT] MATCHED [AND predicates] THEN action'
+// clause of a MERGE query.
+type MergeWhen struct {
+	Matched   bool
+	Predicate VariadicPredicate
+	Action    MergeAction
+	// UPDATE SET
+	Assignments Assignments
+	// INSERT
+	InsertColumns Fields
+	RowValue      RowValue
+}
+
+// MergeQuery represents a MERGE query, available from Postgres 15 onwards.
+type MergeQuery struct {
+	Nested bool
+	Alias  string
+	// WITH
+	CTEs CTEs
+	// MERGE INTO
+	IntoTable BaseTable
+	// USING
+	UsingTable Table
+	// ON
+	OnPredicate VariadicPredicate
+	// WHEN
+	WhenClauses []MergeWhen
+	// DB
+	DB DB
+	// Logging
+	Log     Logger
+	LogFlag LogFlag
+	LogSkip int
+}
+
+// ToSQL marshals the MergeQuery into a query string and args slice.
+func (q MergeQuery) ToSQL() (string, []interface{}) {
+	q.LogSkip += 1
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args)
+	return buf.String(), args
+}
+
+// ToSQLInline marshals the MergeQuery into a query string with the values inlined
+// as SQL literals instead of bind parameters. It is meant for where bind
+// parameters are not allowed e.g. in DDL, otherwise use ToSQL. It returns
+// an error if a value cannot be written as a literal.
+func (q MergeQuery) ToSQLInline() (string, error) {
+	q.Log = nil
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args)
+	query, err := dollarInterpolate(buf.String(), args)
+	if err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
+// AppendSQL marshals the MergeQuery into a buffer and args slice.
+func (q MergeQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
+	var excludedTableQualifiers []string
+	// WITH
+	if len(q.CTEs) > 0 {
+		q.CTEs.AppendSQL(buf, args)
+		buf.WriteString(" ")
+	}
+	// MERGE INTO
+	buf.WriteString("MERGE INTO ")
+	if q.IntoTable == nil {
+		buf.WriteString("NULL")
+	} else {
+		q.IntoTable.AppendSQL(buf, args)
+		name := q.IntoTable.GetName()
+		alias := q.IntoTable.GetAlias()
+		if alias != "" {
+			buf.WriteString(" AS ")
+			appendIdentifier(buf, alias, false)
+			excludedTableQualifiers = append(excludedTableQualifiers, alias)
+		} else {
+			excludedTableQualifiers = append(excludedTableQualifiers, name)
+		}
+	}
+	// USING
+	if q.UsingTable != nil {
+		buf.WriteString(" USING ")
+		appendTable(buf, args, q.UsingTable)
+	}
+	// ON
+	if len(q.OnPredicate.Predicates) > 0 {
+		buf.WriteString(" ON ")
+		q.OnPredicate.Toplevel = true
+		q.OnPredicate.AppendSQLExclude(buf, args, nil)
+	}
+	// WHEN
+	for _, when := range q.WhenClauses {
+		if when.Matched {
+			buf.WriteString(" WHEN MATCHED")
+		} else {
+			buf.WriteString(" WHEN NOT MATCHED")
+		}
+		if len(when.Predicate.Predicates) > 0 {
+			buf.WriteString(" AND ")
+			when.Predicate.Toplevel = false
+			when.Predicate.AppendSQLExclude(buf, args, nil)
+		}
+		buf.WriteString(" THEN ")
+		switch when.Action {
+		case MergeActionUpdate:
+			buf.WriteString("UPDATE SET ")
+			// only the assigned columns are unqualified, the values may
+			// reference columns of either table
+			for i, assignment := range when.Assignments {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				switch v := assignment.(type) {
+				case FieldAssignment:
+					AppendSQLValue(buf, args, excludedTableQualifiers, v.Field)
+					buf.WriteString(" = ")
+					AppendSQLValue(buf, args, nil, v.Value)
+				default:
+					assignment.AppendSQLExclude(buf, args, excludedTableQualifiers)
+				}
+			}
+		case MergeActionInsert:
+			buf.WriteString("INSERT")
+			if len(when.InsertColumns) > 0 {
+				buf.WriteString(" (")
+				when.InsertColumns.AppendSQLExclude(buf, args, excludedTableQualifiers)
+				buf.WriteString(")")
+			}
+			if len(when.RowValue) > 0 {
+				buf.WriteString(" VALUES ")
+				when.RowValue.AppendSQL(buf, args)
+			} else {
+				buf.WriteString(" DEFAULT VALUES")
+			}
+		default:
+			buf.WriteString(string(when.Action))
+		}
+	}
+	if !q.Nested {
+		query := buf.String()
+		buf.Reset()
+		QuestionToDollarPlaceholders(buf, query)
+		if q.Log != nil {
+			var logOutput string
+			switch {
+			case Lstats&q.LogFlag != 0:
+				logOutput = "\n----[ Executing query ]----\n" + buf.String() + " " + fmt.Sprint(*args) +
+					"\n----[ with bind values ]----\n" + QuestionInterpolate(query, *args...)
+			case Linterpolate&q.LogFlag != 0:
+				logOutput = QuestionInterpolate(query, *args...)
+			default:
+				logOutput = buf.String() + " " + fmt.Sprint(*args)
+			}
+			switch q.Log.(type) {
+			case *log.Logger:
+				q.Log.Output(q.LogSkip+2, logOutput)
+			default:
+				q.Log.Output(q.LogSkip+1, logOutput)
+			}
+		}
+	}
+}
+
+// GetAlias implements the Table interface. It returns the alias of the
+// MergeQuery.
+func (q MergeQuery) GetAlias() string {
+	return q.Alias
+}
+
+// GetName implements the Table interface. It always returns an empty string
+// because a MergeQuery does not have a name.
+func (q MergeQuery) GetName() string {
+	return ""
+}
+
+// NestThis implements the Query interface.
+func (q MergeQuery) NestThis() Query {
+	q.Nested = true
+	return q
+}
+
+// As aliases the MergeQuery i.e. 'query AS alias'.
+func (q MergeQuery) As(alias string) MergeQuery {
+	q.Alias = alias
+	return q
+}
+
+// MergeInto creates a new MergeQuery that merges into the table.
+func MergeInto(table BaseTable) MergeQuery {
+	return MergeQuery{
+		IntoTable: table,
+	}
+}
+
+// With appends a list of CTEs into the MergeQuery.
+func (q MergeQuery) With(ctes ...CTE) MergeQuery {
+	q.CTEs = append(q.CTEs, ctes...)
+	return q
+}
+
+// MergeInto sets the target table of the MergeQuery.
+func (q MergeQuery) MergeInto(table BaseTable) MergeQuery {
+	q.IntoTable = table
+	return q
+}
+
+// Using sets the source of the MergeQuery, which can be a table, a CTE or a
+// subquery.
+func (q MergeQuery) Using(table Table) MergeQuery {
+	q.UsingTable = table
+	return q
+}
+
+// On adds the join condition between the target table and the source.
+func (q MergeQuery) On(predicates ...Predicate) MergeQuery {
+	q.OnPredicate.Predicates = append(q.OnPredicate.Predicates, predicates...)
+	return q
+}
+
+// WhenMatched starts a 'WHEN MATCHED [AND predicates]' clause. It must be
+// completed with ThenUpdateSet, ThenDelete or ThenDoNothing.
+func (q MergeQuery) WhenMatched(predicates ...Predicate) MergeMatched {
+	return MergeMatched{
+		mergeQuery: q,
+		predicates: predicates,
+	}
+}
+
+// WhenNotMatched starts a 'WHEN NOT MATCHED [AND predicates]' clause. It must
+// be completed with ThenInsert, ThenInsertDefaultValues or ThenDoNothing.
+func (q MergeQuery) WhenNotMatched(predicates ...Predicate) MergeNotMatched {
+	return MergeNotMatched{
+		mergeQuery: q,
+		predicates: predicates,
+	}
+}
+
+func (q MergeQuery) when(when MergeWhen) MergeQuery {
+	q.WhenClauses = append(append([]MergeWhen{}, q.WhenClauses...), when)
+	return q
+}
+
+// MergeMatched is a 'WHEN MATCHED [AND predicates]' clause of a MergeQuery
+// that has not been given an action yet. It is returned by
+// MergeQuery.WhenMatched.
+type MergeMatched struct {
+	mergeQuery MergeQuery
+	predicates []Predicate
+}
+
+// ThenUpdateSet completes the clause with an 'UPDATE SET assignments...'
+// action. The assignments may reference the columns of the source.
+func (m MergeMatched) ThenUpdateSet(assignments ...Assignment) MergeQuery {
+	return m.mergeQuery.when(MergeWhen{
+		Matched:     true,
+		Predicate:   VariadicPredicate{Predicates: m.predicates},
+		Action:      MergeActionUpdate,
+		Assignments: assignments,
+	})
+}
+
+// ThenDelete completes the clause with a DELETE action, which deletes the
+// matched row of the target table.
+func (m MergeMatched) ThenDelete() MergeQuery {
+	return m.mergeQuery.when(MergeWhen{
+		Matched:   true,
+		Predicate: VariadicPredicate{Predicates: m.predicates},
+		Action:    MergeActionDelete,
+	})
+}
+
+// ThenDoNothing completes the clause with a DO NOTHING action.
+func (m MergeMatched) ThenDoNothing() MergeQuery {
+	return m.mergeQuery.when(MergeWhen{
+		Matched:   true,
+		Predicate: VariadicPredicate{Predicates: m.predicates},
+		Action:    MergeActionDoNothing,
+	})
+}
+
+// MergeNotMatched is a 'WHEN NOT MATCHED [AND predicates]' clause of a
+// MergeQuery that has not been given an action yet. It is returned by
+// MergeQuery.WhenNotMatched.
+type MergeNotMatched struct {
+	mergeQuery MergeQuery
+	predicates []Predicate
+}
+
+// ThenInsert completes the clause with an 'INSERT (columns...) VALUES
+// (values...)' action. The values may reference the columns of the source.
+func (m MergeNotMatched) ThenInsert(columns Fields, values ...interface{}) MergeQuery {
+	return m.mergeQuery.when(MergeWhen{
+		Predicate:     VariadicPredicate{Predicates: m.predicates},
+		Action:        MergeActionInsert,
+		InsertColumns: columns,
+		RowValue:      values,
+	})
+}
+
+// ThenInsertDefaultValues completes the clause with an 'INSERT DEFAULT
+// VALUES' action.
+func (m MergeNotMatched) ThenInsertDefaultValues() MergeQuery {
+	return m.mergeQuery.when(MergeWhen{
+		Predicate: VariadicPredicate{Predicates: m.predicates},
+		Action:    MergeActionInsert,
+	})
+}
+
+// ThenDoNothing completes the clause with a DO NOTHING action.
+func (m MergeNotMatched) ThenDoNothing() MergeQuery {
+	return m.mergeQuery.when(MergeWhen{
+		Predicate: VariadicPredicate{Predicates: m.predicates},
+		Action:    MergeActionDoNothing,
+	})
+}
+
+// Exec will execute the MergeQuery with the given DB. It will only compute the
+// rowsAffected if the ErowsAffected ExecFlag is passed to it. The rows
+// affected are the rows that were updated, deleted or inserted.
+func (q MergeQuery) Exec(db DB, flag ExecFlag) (rowsAffected int64, err error) {
+	q.LogSkip += 1
+	return q.ExecContext(nil, db, flag)
+}
+
+// ExecContext will execute the MergeQuery with the given DB and context. It
+// will only compute the rowsAffected if the ErowsAffected ExecFlag is passed
+// to it.
+func (q MergeQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (rowsAffected int64, err error) {
+	if db == nil {
+		if q.DB == nil {
+			return rowsAffected, errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	logBuf := &strings.Builder{}
+	start := time.Now()
+	defer func() {
+		if q.Log == nil {
+			return
+		}
+		elapsed := time.Since(start)
+		if Lstats&q.LogFlag != 0 && ErowsAffected&flag != 0 {
+			logBuf.WriteString("\n(Merged ")
+			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
+			logBuf.WriteString(" rows in ")
+			logBuf.WriteString(elapsed.String())
+			logBuf.WriteString(")")
+		}
+		if logBuf.Len() > 0 {
+			switch q.Log.(type) {
+			case *log.Logger:
+				q.Log.Output(q.LogSkip+2, logBuf.String())
+			default:
+				q.Log.Output(q.LogSkip+1, logBuf.String())
+			}
+		}
+	}()
+	var res sql.Result
+	tmpbuf := &strings.Builder{}
+	var tmpargs []interface{}
+	q.LogSkip += 1
+	q.AppendSQL(tmpbuf, &tmpargs)
+	if err = ArgsError(tmpargs); err != nil {
+		return rowsAffected, newQueryError("MERGE", tmpbuf.String(), tmpargs, nil, err)
+	}
+	if ctx == nil {
+		res, err = db.Exec(tmpbuf.String(), tmpargs...)
+	} else {
+		res, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
+	}
+	if err != nil {
+		return rowsAffected, newQueryError("MERGE", tmpbuf.String(), tmpargs, nil, err)
+	}
+	if res != nil && ErowsAffected&flag != 0 {
+		rowsAffected, err = res.RowsAffected()
+		if err != nil {
+			return rowsAffected, newQueryError("MERGE", tmpbuf.String(), tmpargs, nil, err)
+		}
+	}
+	return rowsAffected, nil
+}
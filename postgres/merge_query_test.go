@@ -0,0 +1,80 @@
+package sq
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestMergeQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		description string
+		q           MergeQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := USERS().As("u")
+	src := NewValuesTable("src", "user_id", "displayname", "email", "deleted").
+		Row(1, "alice", "alice@email.com", false)
+	srcUserID, srcDisplayname, srcEmail := src.NumberField("user_id"), src.StringField("displayname"), src.StringField("email")
+	tests := []TT{
+		{"empty", MergeQuery{}, "MERGE INTO NULL", nil},
+		{
+			"upsert or delete",
+			WithDefaultLog(Linterpolate).
+				MergeInto(u).
+				Using(src).
+				On(u.USER_ID.Eq(srcUserID)).
+				WhenMatched(src.BooleanField("deleted")).ThenDelete().
+				WhenMatched().ThenUpdateSet(u.DISPLAYNAME.Set(srcDisplayname), u.EMAIL.Set(srcEmail)).
+				WhenNotMatched().ThenInsert(Fields{u.USER_ID, u.DISPLAYNAME, u.EMAIL}, srcUserID, srcDisplayname, srcEmail),
+			"MERGE INTO public.users AS u" +
+				" USING (VALUES ($1::BIGINT, $2::TEXT, $3::TEXT, $4::BOOLEAN)) AS src (user_id, displayname, email, deleted)" +
+				" ON u.user_id = src.user_id" +
+				" WHEN MATCHED AND src.deleted THEN DELETE" +
+				" WHEN MATCHED THEN UPDATE SET displayname = src.displayname, email = src.email" +
+				" WHEN NOT MATCHED THEN INSERT (user_id, displayname, email) VALUES (src.user_id, src.displayname, src.email)",
+			[]interface{}{1, "alice", "alice@email.com", false},
+		},
+		{
+			"subquery source and CTE",
+			func() MergeQuery {
+				cte := NewCTE("new_users", Select(u.USER_ID, u.DISPLAYNAME).From(u).Where(u.EMAIL.LikeString("%@new.com")))
+				s := Select(cte.NumberField("user_id"), cte.StringField("displayname")).From(cte).As("s")
+				return With(cte).
+					MergeInto(u).
+					Using(s).
+					On(u.USER_ID.Eq(s.NumberField("user_id"))).
+					WhenMatched(u.DISPLAYNAME.Ne(s.StringField("displayname"))).ThenUpdateSet(u.DISPLAYNAME.Set(Fieldf("UPPER(?)", u.DISPLAYNAME))).
+					WhenMatched().ThenDoNothing().
+					WhenNotMatched(s.NumberField("user_id").GtInt(0)).ThenInsertDefaultValues().
+					WhenNotMatched().ThenDoNothing()
+			}(),
+			"WITH new_users AS (SELECT u.user_id, u.displayname FROM public.users AS u WHERE u.email LIKE $1)" +
+				" MERGE INTO public.users AS u" +
+				" USING (SELECT new_users.user_id, new_users.displayname FROM new_users) AS s" +
+				" ON u.user_id = s.user_id" +
+				" WHEN MATCHED AND u.displayname <> s.displayname THEN UPDATE SET displayname = UPPER(u.displayname)" +
+				" WHEN MATCHED THEN DO NOTHING" +
+				" WHEN NOT MATCHED AND s.user_id > $2 THEN INSERT DEFAULT VALUES" +
+				" WHEN NOT MATCHED THEN DO NOTHING",
+			[]interface{}{"%@new.com", 0},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestMergeQuery_NilDB(t *testing.T) {
+	is := is.New(t)
+	_, err := MergeInto(USERS()).Exec(nil, ErowsAffected)
+	is.True(err != nil)
+}
@@ -14,8 +14,9 @@ type NumberField struct {
 	// | ? / ?                  | 22, 7       |
 	// | FLOOR(? + tbl.column)  | 5           |
 	// | (ABS(?) + (? % ?)) - ? | -3, 5, 4, 8 |
-	format *string
-	values []interface{}
+	format   *string
+	values   []interface{}
+	compound bool // compound expressions are bracketed when used as operands
 
 	// 2) Literal number value
 	// Examples of literal number values:
@@ -52,33 +53,7 @@ func (f NumberField) AppendSQLExclude(buf *strings.Builder, args *[]interface{},
 		*args = append(*args, f.value)
 	default:
 		// 3) Number column
-		tableQualifier := f.table.GetAlias()
-		if tableQualifier == "" {
-			tableQualifier = f.table.GetName()
-		}
-		for _, excludedTableQualifier := range excludedTableQualifiers {
-			if tableQualifier == excludedTableQualifier {
-				tableQualifier = ""
-				break
-			}
-		}
-		if tableQualifier != "" {
-			if strings.ContainsAny(tableQualifier, " \t") {
-				buf.WriteString(`"`)
-				buf.WriteString(tableQualifier)
-				buf.WriteString(`".`)
-			} else {
-				buf.WriteString(tableQualifier)
-				buf.WriteString(".")
-			}
-		}
-		if strings.ContainsAny(f.name, " \t") {
-			buf.WriteString(`"`)
-			buf.WriteString(f.name)
-			buf.WriteString(`"`)
-		} else {
-			buf.WriteString(f.name)
-		}
+		appendColumn(buf, f.table, f.name, excludedTableQualifiers)
 	}
 	if f.descending != nil {
 		if *f.descending {
@@ -380,6 +355,370 @@ func (f NumberField) In(v interface{}) Predicate {
 	}
 }
 
+// EqAny returns an 'X = ANY(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) EqAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? = ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// EqAll returns an 'X = ALL(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) EqAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? = ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// NeAny returns an 'X <> ANY(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) NeAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? <> ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// NeAll returns an 'X <> ALL(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) NeAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? <> ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// GtAny returns an 'X > ANY(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) GtAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? > ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// GtAll returns an 'X > ALL(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) GtAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? > ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// GeAny returns an 'X >= ANY(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) GeAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? >= ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// GeAll returns an 'X >= ALL(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) GeAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? >= ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// LtAny returns an 'X < ANY(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) LtAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? < ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// LtAll returns an 'X < ALL(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) LtAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? < ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// LeAny returns an 'X <= ANY(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) LeAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? <= ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// LeAll returns an 'X <= ALL(Y)' Predicate. It only accepts ArrayField.
+func (f NumberField) LeAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? <= ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// EqAnyQuery returns an 'X = ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) EqAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? = ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// EqAllQuery returns an 'X = ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) EqAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? = ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// NeAnyQuery returns an 'X <> ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) NeAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? <> ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// NeAllQuery returns an 'X <> ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) NeAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? <> ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// GtAnyQuery returns an 'X > ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) GtAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? > ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// GtAllQuery returns an 'X > ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) GtAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? > ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// GeAnyQuery returns an 'X >= ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) GeAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? >= ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// GeAllQuery returns an 'X >= ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) GeAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? >= ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// LtAnyQuery returns an 'X < ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) LtAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? < ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// LtAllQuery returns an 'X < ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) LtAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? < ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// LeAnyQuery returns an 'X <= ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) LeAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? <= ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// LeAllQuery returns an 'X <= ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) LeAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? <= ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// numberExpression returns a new NumberField representing a compound number
+// expression. Any operands that are themselves compound number expressions are
+// wrapped in brackets, so that operator precedence is preserved no matter how
+// deeply the expressions are nested.
+func numberExpression(format string, values ...interface{}) NumberField {
+	for i, value := range values {
+		if field, ok := value.(NumberField); ok && field.compound {
+			bracketed := "(?)"
+			values[i] = NumberField{
+				format: &bracketed,
+				values: []interface{}{field},
+			}
+		}
+	}
+	return NumberField{
+		format:   &format,
+		values:   values,
+		compound: true,
+	}
+}
+
+// numberFunction returns a new NumberField representing a number function
+// call. Function calls are self-delimiting, so they do not need brackets when
+// used as operands.
+func numberFunction(format string, values ...interface{}) NumberField {
+	return NumberField{
+		format: &format,
+		values: values,
+	}
+}
+
+// Add returns an 'X + Y' NumberField. It only accepts NumberField.
+func (f NumberField) Add(field NumberField) NumberField {
+	return numberExpression("? + ?", f, field)
+}
+
+// AddInt returns an 'X + Y' NumberField. It only accepts int.
+func (f NumberField) AddInt(num int) NumberField {
+	return numberExpression("? + ?", f, num)
+}
+
+// AddFloat64 returns an 'X + Y' NumberField. It only accepts float64.
+func (f NumberField) AddFloat64(num float64) NumberField {
+	return numberExpression("? + ?", f, num)
+}
+
+// Sub returns an 'X - Y' NumberField. It only accepts NumberField.
+func (f NumberField) Sub(field NumberField) NumberField {
+	return numberExpression("? - ?", f, field)
+}
+
+// SubInt returns an 'X - Y' NumberField. It only accepts int.
+func (f NumberField) SubInt(num int) NumberField {
+	return numberExpression("? - ?", f, num)
+}
+
+// SubFloat64 returns an 'X - Y' NumberField. It only accepts float64.
+func (f NumberField) SubFloat64(num float64) NumberField {
+	return numberExpression("? - ?", f, num)
+}
+
+// Mul returns an 'X * Y' NumberField. It only accepts NumberField.
+func (f NumberField) Mul(field NumberField) NumberField {
+	return numberExpression("? * ?", f, field)
+}
+
+// MulInt returns an 'X * Y' NumberField. It only accepts int.
+func (f NumberField) MulInt(num int) NumberField {
+	return numberExpression("? * ?", f, num)
+}
+
+// MulFloat64 returns an 'X * Y' NumberField. It only accepts float64.
+func (f NumberField) MulFloat64(num float64) NumberField {
+	return numberExpression("? * ?", f, num)
+}
+
+// Div returns an 'X / Y' NumberField. It only accepts NumberField.
+func (f NumberField) Div(field NumberField) NumberField {
+	return numberExpression("? / ?", f, field)
+}
+
+// DivInt returns an 'X / Y' NumberField. It only accepts int.
+func (f NumberField) DivInt(num int) NumberField {
+	return numberExpression("? / ?", f, num)
+}
+
+// DivFloat64 returns an 'X / Y' NumberField. It only accepts float64.
+func (f NumberField) DivFloat64(num float64) NumberField {
+	return numberExpression("? / ?", f, num)
+}
+
+// Mod returns an 'X % Y' NumberField. It only accepts NumberField.
+func (f NumberField) Mod(field NumberField) NumberField {
+	return numberExpression("? % ?", f, field)
+}
+
+// ModInt returns an 'X % Y' NumberField. It only accepts int.
+func (f NumberField) ModInt(num int) NumberField {
+	return numberExpression("? % ?", f, num)
+}
+
+// Neg returns a '-X' NumberField.
+func (f NumberField) Neg() NumberField {
+	return numberExpression("-?", f)
+}
+
+// Abs returns an 'ABS(X)' NumberField.
+func (f NumberField) Abs() NumberField {
+	return numberFunction("ABS(?)", f)
+}
+
+// Round returns a 'ROUND(X::NUMERIC, places)' NumberField. X is cast to
+// NUMERIC because Postgres has no ROUND(double precision, integer).
+func (f NumberField) Round(places int) NumberField {
+	return numberFunction("ROUND((?)::NUMERIC, ?)", f, places)
+}
+
+// Ceil returns a 'CEIL(X)' NumberField.
+func (f NumberField) Ceil() NumberField {
+	return numberFunction("CEIL(?)", f)
+}
+
+// Floor returns a 'FLOOR(X)' NumberField.
+func (f NumberField) Floor() NumberField {
+	return numberFunction("FLOOR(?)", f)
+}
+
+// Power returns a 'POWER(X, Y)' NumberField. It only accepts NumberField.
+func (f NumberField) Power(field NumberField) NumberField {
+	return numberFunction("POWER(?, ?)", f, field)
+}
+
+// PowerFloat64 returns a 'POWER(X, Y)' NumberField. It only accepts float64.
+func (f NumberField) PowerFloat64(num float64) NumberField {
+	return numberFunction("POWER(?, ?)", f, num)
+}
+
+// Sqrt returns a 'SQRT(X)' NumberField.
+func (f NumberField) Sqrt() NumberField {
+	return numberFunction("SQRT(?)", f)
+}
+
+// InQuery returns an 'X IN (subquery)' Predicate. The subquery must select
+// exactly one field.
+func (f NumberField) InQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? IN (?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// NotInQuery returns an 'X NOT IN (subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f NumberField) NotInQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? NOT IN (?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a NumberField.
 func (f NumberField) String() string {
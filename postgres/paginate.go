@@ -0,0 +1,340 @@
+package sq
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Page is a page of a SelectQuery paginated by Paginate. HasNext and
+// NextCursor are set when the Query is fetched.
+type Page struct {
+	// Query is the SelectQuery that fetches the page. Its mapper and
+	// accumulator are the ones passed to Paginate, so it is fetched like any
+	// other SelectQuery.
+	Query SelectQuery
+	// HasPrev reports whether there are rows before the page i.e. the page
+	// was requested with a cursor.
+	HasPrev bool
+	// HasNext reports whether there are rows after the page.
+	HasNext bool
+	// NextCursor is the cursor of the next page, or "" if there is no next
+	// page.
+	NextCursor string
+
+	fields   []orderField
+	values   []interface{}
+	pageSize int
+	rowcount int
+	cursor   string
+}
+
+// Paginate returns the page of the query that comes after the cursor, using
+// keyset (seek) pagination instead of OFFSET so that every page is as fast as
+// the first one. The query is ordered by the orderFields, and the rows after
+// the cursor are selected with a WHERE predicate on the orderFields instead of
+// being skipped over.
+//
+// The orderFields must be a unique ordering of the rows (e.g. end with the
+// primary key), otherwise rows that sort equally will be skipped. Their
+// Asc/Desc and NullsFirst/NullsLast settings are respected. Consecutive
+// fields with the same direction are compared as a row value i.e.
+// (a, b) > ($1, $2), and fields with different directions are expanded into
+// an OR chain i.e. a > $1 OR (a = $1 AND b < $2). Fields without NullsFirst or
+// NullsLast are assumed to be NOT NULL.
+//
+// An empty cursor returns the first page. After the Query is fetched,
+// NextCursor is the (opaque) cursor of the next page:
+//
+//	page, err := Paginate(Selectx(mapper, accumulator).From(u), []Field{u.CREATED_AT.Desc(), u.USER_ID.Desc()}, cursor, 20)
+//	if err != nil {
+//		return err
+//	}
+//	err = page.Query.Fetch(db)
+//	if err != nil {
+//		return err
+//	}
+//	if page.HasNext {
+//		// link to the next page with page.NextCursor
+//	}
+func Paginate(query SelectQuery, orderFields []Field, cursor string, pageSize int) (*Page, error) {
+	if query.Mapper == nil {
+		return nil, fmt.Errorf("Cannot paginate without a mapper")
+	}
+	if len(orderFields) == 0 {
+		return nil, fmt.Errorf("Cannot paginate without any order fields")
+	}
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("Cannot paginate with a page size of %d", pageSize)
+	}
+	page := &Page{
+		HasPrev:  cursor != "",
+		fields:   make([]orderField, len(orderFields)),
+		values:   make([]interface{}, len(orderFields)),
+		pageSize: pageSize,
+	}
+	for i, field := range orderFields {
+		page.fields[i] = newOrderField(field)
+	}
+	if cursor != "" {
+		values, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != len(orderFields) {
+			return nil, fmt.Errorf("Cannot paginate with a cursor of %d values for %d order fields", len(values), len(orderFields))
+		}
+		query = query.Where(page.seekPredicate(values))
+	}
+	mapper, accumulator := query.Mapper, query.Accumulator
+	query.Mapper = func(row *Row) {
+		if row.rows == nil {
+			page.rowcount = 0
+			page.HasNext, page.NextCursor = false, ""
+		}
+		mapper(row)
+		for i, field := range page.fields {
+			row.ScanInto(&page.values[i], field)
+		}
+	}
+	query.Accumulator = func() {
+		page.rowcount++
+		if page.rowcount > page.pageSize {
+			// the extra row fetched only to know that there is a next page
+			page.HasNext, page.NextCursor = true, page.cursor
+			panic(ExitPeacefully)
+		}
+		if accumulator != nil {
+			accumulator()
+		}
+		if page.rowcount == page.pageSize {
+			page.cursor = encodeCursor(page.values)
+		}
+	}
+	query.OrderByFields = orderFields
+	query = query.Limit(pageSize + 1)
+	page.Query = query
+	return page, nil
+}
+
+// orderField is an ORDER BY field split into the field itself and its
+// ordering, so that it can be used in the seek predicate.
+type orderField struct {
+	query      string
+	args       []interface{}
+	desc       bool
+	nullsFirst *bool
+}
+
+// newOrderField splits the ORDER BY field into an orderField.
+func newOrderField(field Field) orderField {
+	buf := &strings.Builder{}
+	var f orderField
+	field.AppendSQLExclude(buf, &f.args, nil)
+	f.query = buf.String()
+	if strings.HasSuffix(f.query, " NULLS FIRST") {
+		nullsFirst := true
+		f.query, f.nullsFirst = strings.TrimSuffix(f.query, " NULLS FIRST"), &nullsFirst
+	} else if strings.HasSuffix(f.query, " NULLS LAST") {
+		nullsFirst := false
+		f.query, f.nullsFirst = strings.TrimSuffix(f.query, " NULLS LAST"), &nullsFirst
+	}
+	if strings.HasSuffix(f.query, " DESC") {
+		f.query, f.desc = strings.TrimSuffix(f.query, " DESC"), true
+	} else {
+		f.query = strings.TrimSuffix(f.query, " ASC")
+	}
+	return f
+}
+
+// AppendSQLExclude implements the Field interface.
+func (f orderField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
+	buf.WriteString(f.query)
+	*args = append(*args, f.args...)
+}
+
+// GetAlias implements the Field interface.
+func (f orderField) GetAlias() string { return "" }
+
+// GetName implements the Field interface.
+func (f orderField) GetName() string { return "" }
+
+// isNullsFirst reports whether NULLs of the field are ordered first. Postgres
+// orders NULLs as larger than any other value by default.
+func (f orderField) isNullsFirst() bool {
+	if f.nullsFirst != nil {
+		return *f.nullsFirst
+	}
+	return f.desc
+}
+
+// seekValue is a value of the cursor. It is always a single bind parameter,
+// unlike AppendSQLValue which expands slices (such as []byte).
+type seekValue struct {
+	value interface{}
+}
+
+// AppendSQLExclude implements the Field interface.
+func (v seekValue) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
+	buf.WriteString("?")
+	*args = append(*args, v.value)
+}
+
+// GetAlias implements the Field interface.
+func (v seekValue) GetAlias() string { return "" }
+
+// GetName implements the Field interface.
+func (v seekValue) GetName() string { return "" }
+
+// seekPredicate returns the predicate that selects the rows after the row
+// with the values. The fields are split into runs of consecutive fields with
+// the same direction, so that each run is compared as a row value:
+//
+//	after(run1) OR (equal(run1) AND after(run2)) OR ...
+//
+// Fields with a NULL value or with NullsFirst/NullsLast set are runs of their
+// own, because row value comparisons do not handle NULLs.
+func (page *Page) seekPredicate(values []interface{}) Predicate {
+	var terms, equals []Predicate
+	for i := 0; i < len(page.fields); {
+		j := i + 1
+		if values[i] != nil && page.fields[i].nullsFirst == nil {
+			for j < len(page.fields) && values[j] != nil && page.fields[j].nullsFirst == nil && page.fields[j].desc == page.fields[i].desc {
+				j++
+			}
+		}
+		if after := page.after(page.fields[i:j], values[i:j]); after != nil {
+			terms = append(terms, And(append(equals[:len(equals):len(equals)], after)...))
+		}
+		equals = append(equals, page.equal(page.fields[i:j], values[i:j]))
+		i = j
+	}
+	if len(terms) == 0 {
+		// the row was the last possible row
+		return Predicatef("FALSE")
+	}
+	return Or(terms...)
+}
+
+// after returns the predicate that the run of fields comes after the values,
+// or nil if nothing can come after them.
+func (page *Page) after(fields []orderField, values []interface{}) Predicate {
+	operator := " > "
+	if fields[0].desc {
+		operator = " < "
+	}
+	if len(fields) > 1 {
+		left, right := make(RowValue, len(fields)), make(RowValue, len(values))
+		for i := range fields {
+			left[i], right[i] = fields[i], seekValue{values[i]}
+		}
+		return Predicatef("?"+operator+"?", left, right)
+	}
+	field := fields[0]
+	if values[0] == nil {
+		if field.isNullsFirst() {
+			return Predicatef("? IS NOT NULL", field)
+		}
+		return nil
+	}
+	after := Predicatef("?"+operator+"?", field, seekValue{values[0]})
+	if field.nullsFirst != nil && !field.isNullsFirst() {
+		return Or(after, Predicatef("? IS NULL", field))
+	}
+	return after
+}
+
+// equal returns the predicate that the run of fields are equal to the values.
+func (page *Page) equal(fields []orderField, values []interface{}) Predicate {
+	if len(fields) > 1 {
+		left, right := make(RowValue, len(fields)), make(RowValue, len(values))
+		for i := range fields {
+			left[i], right[i] = fields[i], seekValue{values[i]}
+		}
+		return Predicatef("? = ?", left, right)
+	}
+	if values[0] == nil {
+		return Predicatef("? IS NULL", fields[0])
+	}
+	return Predicatef("? = ?", fields[0], seekValue{values[0]})
+}
+
+// cursorValue is a value of a cursor, tagged with its type so that it is
+// decoded back into the same type.
+type cursorValue struct {
+	Type  string `json:"t"`
+	Value string `json:"v,omitempty"`
+}
+
+// encodeCursor encodes the values of a row into an opaque cursor.
+func encodeCursor(values []interface{}) string {
+	cvs := make([]cursorValue, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			cvs[i] = cursorValue{Type: "null"}
+		case bool:
+			cvs[i] = cursorValue{Type: "bool", Value: strconv.FormatBool(v)}
+		case int64:
+			cvs[i] = cursorValue{Type: "int", Value: strconv.FormatInt(v, 10)}
+		case uint64:
+			cvs[i] = cursorValue{Type: "uint", Value: strconv.FormatUint(v, 10)}
+		case float64:
+			cvs[i] = cursorValue{Type: "float", Value: strconv.FormatFloat(v, 'g', -1, 64)}
+		case string:
+			cvs[i] = cursorValue{Type: "string", Value: v}
+		case []byte:
+			cvs[i] = cursorValue{Type: "bytes", Value: base64.StdEncoding.EncodeToString(v)}
+		case time.Time:
+			cvs[i] = cursorValue{Type: "time", Value: v.Format(time.RFC3339Nano)}
+		default:
+			cvs[i] = cursorValue{Type: "string", Value: fmt.Sprint(v)}
+		}
+	}
+	b, _ := json.Marshal(cvs)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor decodes the values of a row from a cursor returned by
+// encodeCursor.
+func decodeCursor(cursor string) ([]interface{}, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid pagination cursor: %w", err)
+	}
+	var cvs []cursorValue
+	err = json.Unmarshal(b, &cvs)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid pagination cursor: %w", err)
+	}
+	values := make([]interface{}, len(cvs))
+	for i, cv := range cvs {
+		switch cv.Type {
+		case "null":
+			values[i] = nil
+		case "bool":
+			values[i], err = strconv.ParseBool(cv.Value)
+		case "int":
+			values[i], err = strconv.ParseInt(cv.Value, 10, 64)
+		case "uint":
+			values[i], err = strconv.ParseUint(cv.Value, 10, 64)
+		case "float":
+			values[i], err = strconv.ParseFloat(cv.Value, 64)
+		case "string":
+			values[i] = cv.Value
+		case "bytes":
+			values[i], err = base64.StdEncoding.DecodeString(cv.Value)
+		case "time":
+			values[i], err = time.Parse(time.RFC3339Nano, cv.Value)
+		default:
+			err = fmt.Errorf("unknown type %q", cv.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid pagination cursor: %w", err)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,192 @@
+package sq
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestPaginate(t *testing.T) {
+	type TT struct {
+		description string
+		orderFields func(u TABLE_USERS) []Field
+		values      []interface{}
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		{
+			description: "first page",
+			orderFields: func(u TABLE_USERS) []Field { return []Field{u.USER_ID} },
+			wantQuery:   "SELECT u.user_id FROM public.users AS u ORDER BY u.user_id LIMIT $1",
+			wantArgs:    []interface{}{int64(21)},
+		},
+		{
+			description: "single field",
+			orderFields: func(u TABLE_USERS) []Field { return []Field{u.USER_ID.Desc()} },
+			values:      []interface{}{int64(5)},
+			wantQuery:   "SELECT u.user_id FROM public.users AS u WHERE u.user_id < $1 ORDER BY u.user_id DESC LIMIT $2",
+			wantArgs:    []interface{}{int64(5), int64(21)},
+		},
+		{
+			description: "same directions use a row value",
+			orderFields: func(u TABLE_USERS) []Field { return []Field{u.DISPLAYNAME.Asc(), u.USER_ID} },
+			values:      []interface{}{"bob", int64(5)},
+			wantQuery: "SELECT u.user_id FROM public.users AS u WHERE (u.displayname, u.user_id) > ($1, $2)" +
+				" ORDER BY u.displayname ASC, u.user_id LIMIT $3",
+			wantArgs: []interface{}{"bob", int64(5), int64(21)},
+		},
+		{
+			description: "different directions are expanded",
+			orderFields: func(u TABLE_USERS) []Field { return []Field{u.DISPLAYNAME.Desc(), u.EMAIL, u.USER_ID} },
+			values:      []interface{}{"bob", "bob@example.com", int64(5)},
+			wantQuery: "SELECT u.user_id FROM public.users AS u WHERE u.displayname < $1" +
+				" OR (u.displayname = $2 AND (u.email, u.user_id) > ($3, $4))" +
+				" ORDER BY u.displayname DESC, u.email, u.user_id LIMIT $5",
+			wantArgs: []interface{}{"bob", "bob", "bob@example.com", int64(5), int64(21)},
+		},
+		{
+			description: "nulls last",
+			orderFields: func(u TABLE_USERS) []Field { return []Field{u.DISPLAYNAME.NullsLast(), u.USER_ID} },
+			values:      []interface{}{"bob", int64(5)},
+			wantQuery: "SELECT u.user_id FROM public.users AS u WHERE (u.displayname > $1 OR u.displayname IS NULL)" +
+				" OR (u.displayname = $2 AND u.user_id > $3)" +
+				" ORDER BY u.displayname NULLS LAST, u.user_id LIMIT $4",
+			wantArgs: []interface{}{"bob", "bob", int64(5), int64(21)},
+		},
+		{
+			description: "NULL value with nulls first",
+			orderFields: func(u TABLE_USERS) []Field { return []Field{u.DISPLAYNAME.Asc().NullsFirst(), u.USER_ID} },
+			values:      []interface{}{nil, int64(5)},
+			wantQuery: "SELECT u.user_id FROM public.users AS u WHERE u.displayname IS NOT NULL" +
+				" OR (u.displayname IS NULL AND u.user_id > $1)" +
+				" ORDER BY u.displayname ASC NULLS FIRST, u.user_id LIMIT $2",
+			wantArgs: []interface{}{int64(5), int64(21)},
+		},
+		{
+			description: "NULL value with nulls last",
+			orderFields: func(u TABLE_USERS) []Field { return []Field{u.DISPLAYNAME, u.USER_ID} },
+			values:      []interface{}{nil, int64(5)},
+			wantQuery: "SELECT u.user_id FROM public.users AS u WHERE u.displayname IS NULL AND u.user_id > $1" +
+				" ORDER BY u.displayname, u.user_id LIMIT $2",
+			wantArgs: []interface{}{int64(5), int64(21)},
+		},
+		{
+			description: "last possible row",
+			orderFields: func(u TABLE_USERS) []Field { return []Field{u.DISPLAYNAME} },
+			values:      []interface{}{nil},
+			wantQuery:   "SELECT u.user_id FROM public.users AS u WHERE FALSE ORDER BY u.displayname LIMIT $1",
+			wantArgs:    []interface{}{int64(21)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			u := USERS().As("u")
+			var cursor string
+			if tt.values != nil {
+				cursor = encodeCursor(tt.values)
+			}
+			page, err := Paginate(SelectRowx(func(row *Row) {}).From(u), tt.orderFields(u), cursor, 20)
+			is.NoErr(err)
+			is.Equal(tt.values != nil, page.HasPrev)
+			gotQuery, gotArgs := page.Query.Select(u.USER_ID).ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestPaginate_Cursor(t *testing.T) {
+	is := is.New(t)
+	values := []interface{}{
+		nil, true, int64(-5), uint64(18446744073709551615), 3.14159, "lorem ipsum",
+		[]byte{0xde, 0xad, 0xbe, 0xef}, time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+	}
+	got, err := decodeCursor(encodeCursor(values))
+	is.NoErr(err)
+	is.Equal(values, got)
+
+	u := USERS()
+	query := Selectx(func(row *Row) {}, func() {}).From(u)
+	_, err = Paginate(query, []Field{u.USER_ID}, "not a cursor", 20)
+	is.True(err != nil)
+	_, err = Paginate(query, []Field{u.USER_ID, u.EMAIL}, encodeCursor([]interface{}{int64(1)}), 20)
+	is.True(err != nil)
+	_, err = Paginate(query, nil, "", 20)
+	is.True(err != nil)
+	_, err = Paginate(query, []Field{u.USER_ID}, "", 0)
+	is.True(err != nil)
+	_, err = Paginate(From(u), []Field{u.USER_ID}, "", 20)
+	is.True(err != nil)
+}
+
+func TestPaginate_NextCursor(t *testing.T) {
+	is := is.New(t)
+	db, err := sql.Open("widerows", "5")
+	is.NoErr(err)
+	defer db.Close()
+	tbl := &TableInfo{Name: "wide"}
+	id := NewNumberField("id", tbl)
+	var n int
+	var ns []int
+	query := Selectx(func(row *Row) { n = row.Int(id) }, func() { ns = append(ns, n) }).From(tbl)
+
+	// The page is followed by more rows
+	page, err := Paginate(query, []Field{id}, "", 2)
+	is.NoErr(err)
+	is.NoErr(page.Query.Fetch(db))
+	is.Equal([]int{0, 1}, ns)
+	is.True(page.HasNext)
+	values, err := decodeCursor(page.NextCursor)
+	is.NoErr(err)
+	is.Equal([]interface{}{int64(1)}, values)
+
+	// The page is the last page
+	ns = nil
+	page, err = Paginate(query, []Field{id}, page.NextCursor, 10)
+	is.NoErr(err)
+	is.NoErr(page.Query.Fetch(db))
+	is.Equal(5, len(ns))
+	is.True(page.HasPrev)
+	is.True(!page.HasNext)
+	is.Equal("", page.NextCursor)
+}
+
+func TestPaginate_Fetch(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+	is := is.New(t)
+	db, err := sql.Open("txdb", "Paginate_Fetch")
+	is.NoErr(err)
+	defer db.Close()
+	u := USERS()
+	user := &User{}
+	var users, allUsers []User
+	query := WithDB(db).From(u).Selectx(user.RowMapper(u), func() { users = append(users, *user) })
+	orderFields := []Field{u.DISPLAYNAME.Desc(), u.USER_ID}
+	var cursor string
+	for {
+		users = users[:0]
+		page, err := Paginate(query, orderFields, cursor, 3)
+		is.NoErr(err)
+		is.NoErr(page.Query.Fetch(nil))
+		is.True(len(users) <= 3)
+		allUsers = append(allUsers, users...)
+		if !page.HasNext {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	var wantUsers []User
+	err = WithDB(db).From(u).OrderBy(orderFields...).
+		Selectx(user.RowMapper(u), func() { wantUsers = append(wantUsers, *user) }).
+		Fetch(nil)
+	is.NoErr(err)
+	is.Equal(wantUsers, allUsers)
+}
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -68,6 +67,22 @@ func (q SelectQuery) ToSQL() (string, []interface{}) {
 	return buf.String(), args
 }
 
+// ToSQLInline marshals the SelectQuery into a query string with the values inlined
+// as SQL literals instead of bind parameters. It is meant for where bind
+// parameters are not allowed e.g. in DDL, otherwise use ToSQL. It returns
+// an error if a value cannot be written as a literal.
+func (q SelectQuery) ToSQLInline() (string, error) {
+	q.Log = nil
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args)
+	query, err := dollarInterpolate(buf.String(), args)
+	if err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
 // AppendSQL marshals the SelectQuery into a buffer and args slice.
 func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	// WITH
@@ -92,19 +107,7 @@ func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	// FROM
 	if q.FromTable != nil {
 		buf.WriteString(" FROM ")
-		switch v := q.FromTable.(type) {
-		case Query:
-			buf.WriteString("(")
-			v.NestThis().AppendSQL(buf, args)
-			buf.WriteString(")")
-		default:
-			q.FromTable.AppendSQL(buf, args)
-		}
-		alias := q.FromTable.GetAlias()
-		if alias != "" {
-			buf.WriteString(" AS ")
-			buf.WriteString(alias)
-		}
+		appendTable(buf, args, q.FromTable)
 	}
 	// JOIN
 	if len(q.JoinTables) > 0 {
@@ -183,7 +186,6 @@ func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 func From(table Table) SelectQuery {
 	return SelectQuery{
 		FromTable: table,
-		Alias:     RandomString(8),
 	}
 }
 
@@ -191,7 +193,6 @@ func From(table Table) SelectQuery {
 func Select(fields ...Field) SelectQuery {
 	return SelectQuery{
 		SelectFields: fields,
-		Alias:        RandomString(8),
 	}
 }
 
@@ -199,7 +200,6 @@ func Select(fields ...Field) SelectQuery {
 func SelectOne() SelectQuery {
 	return SelectQuery{
 		SelectFields: Fields{FieldLiteral("1")},
-		Alias:        RandomString(8),
 	}
 }
 
@@ -208,7 +208,6 @@ func SelectDistinct(fields ...Field) SelectQuery {
 	return SelectQuery{
 		SelectType:   SelectTypeDistinct,
 		SelectFields: fields,
-		Alias:        RandomString(8),
 	}
 }
 
@@ -219,7 +218,6 @@ func SelectDistinctOn(distinctFields ...Field) func(...Field) SelectQuery {
 			SelectType:   SelectTypeDistinctOn,
 			SelectFields: fields,
 			DistinctOn:   distinctFields,
-			Alias:        RandomString(8),
 		}
 	}
 }
@@ -229,7 +227,6 @@ func Selectx(mapper func(*Row), accumulator func()) SelectQuery {
 	return SelectQuery{
 		Mapper:      mapper,
 		Accumulator: accumulator,
-		Alias:       RandomString(8),
 	}
 }
 
@@ -237,7 +234,6 @@ func Selectx(mapper func(*Row), accumulator func()) SelectQuery {
 func SelectRowx(mapper func(*Row)) SelectQuery {
 	return SelectQuery{
 		Mapper: mapper,
-		Alias:  RandomString(8),
 	}
 }
 
@@ -437,20 +433,29 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	if q.Mapper == nil {
 		return fmt.Errorf("Cannot call Fetch without a mapper")
 	}
+	r := &Row{}
+	buf := &strings.Builder{}
+	var args []interface{}
 	logBuf := &strings.Builder{}
 	start := time.Now()
 	var rowcount int
 	defer func() {
-		if r := recover(); r != nil {
-			switch v := r.(type) {
+		if p := recover(); p != nil {
+			switch v := p.(type) {
 			case ExitCode:
 				if v != ExitPeacefully {
 					err = v
 				}
+				return
 			case error:
 				err = v
 			default:
-				err = fmt.Errorf("%#v", r)
+				err = fmt.Errorf("%#v", p)
+			}
+			// a panic in the first call of the mapper happens before the
+			// query is built, so there is no query to wrap the error with
+			if buf.Len() > 0 {
+				err = newQueryError("SELECT", buf.String(), args, r.fields, err)
 			}
 			return
 		}
@@ -477,40 +482,32 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			}
 		}
 	}()
-	r := &Row{}
 	q.Mapper(r)
 	q.SelectFields = r.fields
-	tmpbuf := &strings.Builder{}
-	var tmpargs []interface{}
 	q.LogSkip += 1
-	q.AppendSQL(tmpbuf, &tmpargs)
+	q.AppendSQL(buf, &args)
+	if err = ArgsError(args); err != nil {
+		return newQueryError("SELECT", buf.String(), args, nil, err)
+	}
 	if ctx == nil {
-		r.rows, err = db.Query(tmpbuf.String(), tmpargs...)
+		r.rows, err = db.Query(buf.String(), args...)
 	} else {
-		r.rows, err = db.QueryContext(ctx, tmpbuf.String(), tmpargs...)
+		r.rows, err = db.QueryContext(ctx, buf.String(), args...)
 	}
 	if err != nil {
-		return err
+		return newQueryError("SELECT", buf.String(), args, nil, err)
 	}
+	tmpbuf := &strings.Builder{}
+	var tmpargs []interface{}
 	defer r.rows.Close()
 	if len(r.dest) == 0 {
 		return nil
 	}
 	for r.rows.Next() {
 		rowcount++
-		err = r.rows.Scan(r.dest...)
+		err = r.scan()
 		if err != nil {
-			errbuf := &strings.Builder{}
-			for i := range r.dest {
-				tmpbuf.Reset()
-				tmpargs = tmpargs[:0]
-				r.fields[i].AppendSQLExclude(tmpbuf, &tmpargs, nil)
-				errbuf.WriteString("\n" +
-					strconv.Itoa(i) + ") " +
-					DollarInterpolate(tmpbuf.String(), tmpargs...) + " => " +
-					reflect.TypeOf(r.dest[i]).String())
-			}
-			return fmt.Errorf("Please check if your mapper function is correct:%s\n%w", errbuf.String(), err)
+			return newQueryError("SELECT", buf.String(), args, r.fields, err)
 		}
 		if q.Log != nil && Lresults&q.LogFlag != 0 && rowcount <= 5 {
 			logBuf.WriteString("\n----[ Row ")
@@ -537,9 +534,35 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 		return sql.ErrNoRows
 	}
 	if e := r.rows.Close(); e != nil {
-		return e
+		return newQueryError("SELECT", buf.String(), args, nil, e)
+	}
+	return newQueryError("SELECT", buf.String(), args, nil, r.rows.Err())
+}
+
+// Cursor runs the SelectQuery with the given DB and context and returns a Cursor
+// that maps its rows one at a time with the mapper. If the mapper is nil, the
+// mapper set by Selectx or SelectRowx is used instead. The context may be nil.
+func (q SelectQuery) Cursor(ctx context.Context, db DB, mapper func(*Row)) (*Cursor, error) {
+	if db == nil {
+		if q.DB == nil {
+			return nil, errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	if mapper == nil {
+		mapper = q.Mapper
 	}
-	return r.rows.Err()
+	if mapper == nil {
+		return nil, fmt.Errorf("Cannot call Cursor without a mapper")
+	}
+	r := &Row{}
+	mapper(r)
+	q.SelectFields = r.fields
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.LogSkip += 1
+	q.AppendSQL(buf, &args)
+	return openCursor(ctx, db, "SELECT", buf.String(), args, r, mapper, q.Log, q.LogFlag)
 }
 
 // Exec will execute the SelectQuery with the given DB. It will only compute
@@ -586,18 +609,21 @@ func (q SelectQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	var tmpargs []interface{}
 	q.LogSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs)
+	if err = ArgsError(tmpargs); err != nil {
+		return rowsAffected, newQueryError("SELECT", tmpbuf.String(), tmpargs, nil, err)
+	}
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
 		res, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return rowsAffected, err
+		return rowsAffected, newQueryError("SELECT", tmpbuf.String(), tmpargs, nil, err)
 	}
 	if res != nil && ErowsAffected&flag != 0 {
 		rowsAffected, err = res.RowsAffected()
 		if err != nil {
-			return rowsAffected, err
+			return rowsAffected, newQueryError("SELECT", tmpbuf.String(), tmpargs, nil, err)
 		}
 	}
 	return rowsAffected, nil
@@ -612,10 +638,52 @@ func (q SelectQuery) As(alias string) SelectQuery {
 // Get returns a Field from the SelectQuery, identified by fieldName.
 func (q SelectQuery) Get(fieldName string) CustomField {
 	return CustomField{
-		Format: q.Alias + "." + fieldName,
+		Format: qualify(derivedAlias(q), fieldName),
 	}
 }
 
+// NumberField returns the NumberField column identified by name of the
+// SelectQuery when used as a derived table (a subquery in the FROM clause). The
+// column must appear in the select list of the SelectQuery.
+func (q SelectQuery) NumberField(name string) NumberField {
+	return derivedNumberField(q, q, nil, name)
+}
+
+// StringField returns the StringField column identified by name of the
+// SelectQuery when used as a derived table (a subquery in the FROM clause). The
+// column must appear in the select list of the SelectQuery.
+func (q SelectQuery) StringField(name string) StringField {
+	return derivedStringField(q, q, nil, name)
+}
+
+// TimeField returns the TimeField column identified by name of the SelectQuery
+// when used as a derived table (a subquery in the FROM clause). The column must
+// appear in the select list of the SelectQuery.
+func (q SelectQuery) TimeField(name string) TimeField {
+	return derivedTimeField(q, q, nil, name)
+}
+
+// BooleanField returns the BooleanField column identified by name of the
+// SelectQuery when used as a derived table (a subquery in the FROM clause). The
+// column must appear in the select list of the SelectQuery.
+func (q SelectQuery) BooleanField(name string) BooleanField {
+	return derivedBooleanField(q, q, nil, name)
+}
+
+// JSONField returns the JSONField column identified by name of the SelectQuery
+// when used as a derived table (a subquery in the FROM clause). The column must
+// appear in the select list of the SelectQuery.
+func (q SelectQuery) JSONField(name string) JSONField {
+	return derivedJSONField(q, q, nil, name)
+}
+
+// ArrayField returns the ArrayField column identified by name of the
+// SelectQuery when used as a derived table (a subquery in the FROM clause). The
+// column must appear in the select list of the SelectQuery.
+func (q SelectQuery) ArrayField(name string) ArrayField {
+	return derivedArrayField(q, q, nil, name)
+}
+
 // GetAlias returns the alias of the SelectQuery.
 func (q SelectQuery) GetAlias() string {
 	return q.Alias
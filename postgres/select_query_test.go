@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"testing"
 
 	"github.com/matryer/is"
@@ -255,6 +257,98 @@ func TestSelectQuery_Fetch(t *testing.T) {
 	is.Equal(fmt.Errorf("0").Error(), err.Error())
 }
 
+func TestSelectQuery_Cursor(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+	is := is.New(t)
+	db, err := sql.Open("txdb", "SelectQuery_Cursor")
+	is.NoErr(err)
+	defer db.Close()
+	u := USERS()
+
+	// Missing DB
+	_, err = From(u).Cursor(nil, nil, func(row *Row) {})
+	is.True(err != nil)
+
+	// No mapper
+	_, err = WithDB(db).From(u).Cursor(nil, nil, nil)
+	is.True(err != nil)
+
+	// Iterate
+	user := &User{}
+	var users []User
+	cur, err := WithDefaultLog(Lverbose).
+		From(u).
+		OrderBy(u.USER_ID).
+		Limit(10).
+		Cursor(context.Background(), db, user.RowMapper(u))
+	is.NoErr(err)
+	for cur.Next() {
+		users = append(users, *user)
+	}
+	is.NoErr(cur.Err())
+	is.NoErr(cur.Close())
+	is.Equal(10, len(users))
+	is.True(users[0].UserID < users[1].UserID)
+
+	// Stop early
+	cur, err = WithDB(db).
+		From(u).
+		OrderBy(u.USER_ID).
+		Selectx(user.RowMapper(u), nil).
+		Cursor(nil, nil, nil)
+	is.NoErr(err)
+	is.True(cur.Next())
+	is.NoErr(cur.Close())
+	is.True(!cur.Next())
+	is.NoErr(cur.Err())
+
+	// Scan error is returned as a value
+	var displayname int
+	cur, err = WithDB(db).
+		From(u).
+		Cursor(nil, nil, func(row *Row) {
+			row.ScanInto(&displayname, u.DISPLAYNAME)
+		})
+	is.NoErr(err)
+	is.True(!cur.Next())
+	is.True(cur.Err() != nil)
+	is.NoErr(cur.Close())
+}
+
+func TestSelectQuery_CursorLog(t *testing.T) {
+	is := is.New(t)
+	db, err := sql.Open("widerows", "2")
+	is.NoErr(err)
+	defer db.Close()
+	tbl := &TableInfo{Name: "wide"}
+	id := NewNumberField("id", tbl)
+	buf := &strings.Builder{}
+	q := Selectx(func(row *Row) { row.Int(id) }, func() {}).From(tbl)
+	q.Log, q.LogFlag = log.New(buf, "", log.Lshortfile), Lverbose
+
+	// Cursor logs the query, rows and stats the same way as Fetch
+	is.NoErr(q.Fetch(db))
+	fetchLog := buf.String()
+	buf.Reset()
+	cur, err := q.Cursor(nil, db, nil)
+	is.NoErr(err)
+	for cur.Next() {
+	}
+	is.NoErr(cur.Err())
+	cursorLog := buf.String()
+	for _, s := range []string{
+		"select_query_test.go:",
+		"----[ Executing query ]----\nSELECT wide.id FROM wide",
+		"----[ Row 2 ]----\nwide.id: 1",
+		"(Fetched 2 rows in ",
+	} {
+		is.True(strings.Contains(fetchLog, s))
+		is.True(strings.Contains(cursorLog, s))
+	}
+}
+
 func TestSelectQuery_Exec(t *testing.T) {
 	if testing.Short() {
 		return
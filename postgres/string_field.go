@@ -10,18 +10,29 @@ func NewEnumField(name string, table Table) EnumField {
 	return NewStringField(name, table)
 }
 
-// StringField either represents a string column or a literal string value.
+// StringField either represents a string column, a string expression or a
+// literal string value.
 type StringField struct {
 	// StringField will be one of the following:
 
-	// 1) Literal string value
+	// 1) String expression
+	// Examples of string expressions:
+	// | query                  | args |
+	// |------------------------|------|
+	// | LOWER(users.email)     |      |
+	// | LPAD(users.name, ?, ?) | 8, * |
+	format   *string
+	values   []interface{}
+	compound bool // compound expressions are bracketed when used as operands
+
+	// 2) Literal string value
 	// Examples of literal string values:
 	// | query | args |
 	// |-------|------|
 	// | ?     | abcd |
 	value *string
 
-	// 2) String column
+	// 3) String column
 	// Examples of boolean columns:
 	// | query       | args |
 	// |-------------|------|
@@ -39,39 +50,16 @@ type StringField struct {
 // described in the StringField internal struct comments.
 func (f StringField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
 	switch {
+	case f.format != nil:
+		// 1) String expression
+		ExpandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
 	case f.value != nil:
-		// 1) Literal string value
+		// 2) Literal string value
 		buf.WriteString("?")
 		*args = append(*args, *f.value)
 	default:
-		// 2) String column
-		tableQualifier := f.table.GetAlias()
-		if tableQualifier == "" {
-			tableQualifier = f.table.GetName()
-		}
-		for _, excludedTableQualifier := range excludedTableQualifiers {
-			if tableQualifier == excludedTableQualifier {
-				tableQualifier = ""
-				break
-			}
-		}
-		if tableQualifier != "" {
-			if strings.ContainsAny(tableQualifier, " \t") {
-				buf.WriteString(`"`)
-				buf.WriteString(tableQualifier)
-				buf.WriteString(`".`)
-			} else {
-				buf.WriteString(tableQualifier)
-				buf.WriteString(".")
-			}
-		}
-		if strings.ContainsAny(f.name, " \t") {
-			buf.WriteString(`"`)
-			buf.WriteString(f.name)
-			buf.WriteString(`"`)
-		} else {
-			buf.WriteString(f.name)
-		}
+		// 3) String column
+		appendColumn(buf, f.table, f.name, excludedTableQualifiers)
 	}
 	if f.descending != nil {
 		if *f.descending {
@@ -304,6 +292,56 @@ func (f StringField) NotILikeString(s string) Predicate {
 	}
 }
 
+// Matches returns an 'A ~ B' Predicate, which checks if A matches the POSIX
+// regular expression B. The pattern is bound as an argument.
+func (f StringField) Matches(pattern string) Predicate {
+	return CustomPredicate{
+		Format: "? ~ ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// MatchesInsensitive returns an 'A ~* B' Predicate, which checks if A matches
+// the POSIX regular expression B case insensitively.
+func (f StringField) MatchesInsensitive(pattern string) Predicate {
+	return CustomPredicate{
+		Format: "? ~* ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// NotMatches returns an 'A !~ B' Predicate.
+func (f StringField) NotMatches(pattern string) Predicate {
+	return CustomPredicate{
+		Format: "? !~ ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// NotMatchesInsensitive returns an 'A !~* B' Predicate.
+func (f StringField) NotMatchesInsensitive(pattern string) Predicate {
+	return CustomPredicate{
+		Format: "? !~* ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// SimilarTo returns an 'A SIMILAR TO B' Predicate.
+func (f StringField) SimilarTo(pattern string) Predicate {
+	return CustomPredicate{
+		Format: "? SIMILAR TO ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// NotSimilarTo returns an 'A NOT SIMILAR TO B' Predicate.
+func (f StringField) NotSimilarTo(pattern string) Predicate {
+	return CustomPredicate{
+		Format: "? NOT SIMILAR TO ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
 // In returns an 'X IN (Y)' Predicate.
 func (f StringField) In(v interface{}) Predicate {
 	var format string
@@ -325,6 +363,389 @@ func (f StringField) In(v interface{}) Predicate {
 	}
 }
 
+// EqAny returns an 'X = ANY(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) EqAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? = ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// EqAll returns an 'X = ALL(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) EqAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? = ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// NeAny returns an 'X <> ANY(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) NeAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? <> ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// NeAll returns an 'X <> ALL(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) NeAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? <> ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// GtAny returns an 'X > ANY(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) GtAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? > ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// GtAll returns an 'X > ALL(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) GtAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? > ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// GeAny returns an 'X >= ANY(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) GeAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? >= ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// GeAll returns an 'X >= ALL(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) GeAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? >= ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// LtAny returns an 'X < ANY(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) LtAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? < ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// LtAll returns an 'X < ALL(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) LtAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? < ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// LeAny returns an 'X <= ANY(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) LeAny(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? <= ANY(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// LeAll returns an 'X <= ALL(Y)' Predicate. It only accepts ArrayField.
+func (f StringField) LeAll(array ArrayField) Predicate {
+	return CustomPredicate{
+		Format: "? <= ALL(?)",
+		Values: []interface{}{f, array},
+	}
+}
+
+// EqAnyQuery returns an 'X = ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) EqAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? = ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// EqAllQuery returns an 'X = ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) EqAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? = ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// NeAnyQuery returns an 'X <> ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) NeAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? <> ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// NeAllQuery returns an 'X <> ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) NeAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? <> ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// GtAnyQuery returns an 'X > ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) GtAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? > ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// GtAllQuery returns an 'X > ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) GtAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? > ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// GeAnyQuery returns an 'X >= ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) GeAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? >= ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// GeAllQuery returns an 'X >= ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) GeAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? >= ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// LtAnyQuery returns an 'X < ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) LtAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? < ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// LtAllQuery returns an 'X < ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) LtAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? < ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// LeAnyQuery returns an 'X <= ANY(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) LeAnyQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? <= ANY(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// LeAllQuery returns an 'X <= ALL(subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) LeAllQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? <= ALL(?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// stringExpression returns a new StringField representing a compound string
+// expression. Any operands that are themselves compound string expressions are
+// wrapped in brackets.
+func stringExpression(format string, values ...interface{}) StringField {
+	for i, value := range values {
+		if field, ok := value.(StringField); ok && field.compound {
+			bracketed := "(?)"
+			values[i] = StringField{
+				format: &bracketed,
+				values: []interface{}{field},
+			}
+		}
+	}
+	return StringField{
+		format:   &format,
+		values:   values,
+		compound: true,
+	}
+}
+
+// stringFunction returns a new StringField representing a string function
+// call.
+func stringFunction(format string, values ...interface{}) StringField {
+	return StringField{
+		format: &format,
+		values: values,
+	}
+}
+
+// Lower returns a 'LOWER(X)' StringField.
+func Lower(field StringField) StringField {
+	return field.Lower()
+}
+
+// Upper returns an 'UPPER(X)' StringField.
+func Upper(field StringField) StringField {
+	return field.Upper()
+}
+
+// Concat returns a StringField that concatenates all of the StringFields
+// together.
+func Concat(field StringField, fields ...StringField) StringField {
+	return field.Concat(fields...)
+}
+
+// Lower returns a 'LOWER(X)' StringField.
+func (f StringField) Lower() StringField {
+	return stringFunction("LOWER(?)", f)
+}
+
+// Upper returns an 'UPPER(X)' StringField.
+func (f StringField) Upper() StringField {
+	return stringFunction("UPPER(?)", f)
+}
+
+// Trim returns a 'TRIM(X)' StringField.
+func (f StringField) Trim() StringField {
+	return stringFunction("TRIM(?)", f)
+}
+
+// LTrim returns an 'LTRIM(X)' StringField.
+func (f StringField) LTrim() StringField {
+	return stringFunction("LTRIM(?)", f)
+}
+
+// RTrim returns an 'RTRIM(X)' StringField.
+func (f StringField) RTrim() StringField {
+	return stringFunction("RTRIM(?)", f)
+}
+
+// Length returns a 'CHAR_LENGTH(X)' NumberField. It counts characters, not
+// bytes.
+func (f StringField) Length() NumberField {
+	return numberFunction("CHAR_LENGTH(?)", f)
+}
+
+// Substring returns a 'SUBSTRING(X FROM start FOR length)' StringField. start
+// is 1-indexed.
+func (f StringField) Substring(start, length int) StringField {
+	return stringFunction("SUBSTRING(? FROM ? FOR ?)", f, start, length)
+}
+
+// Concat returns an 'X || Y || Z...' StringField.
+func (f StringField) Concat(fields ...StringField) StringField {
+	values := make([]interface{}, 0, len(fields)+1)
+	values = append(values, f)
+	for _, field := range fields {
+		values = append(values, field)
+	}
+	return stringExpression("?"+strings.Repeat(" || ?", len(fields)), values...)
+}
+
+// Replace returns a 'REPLACE(X, from, to)' StringField.
+func (f StringField) Replace(from, to string) StringField {
+	return stringFunction("REPLACE(?, ?, ?)", f, from, to)
+}
+
+// Left returns a 'LEFT(X, n)' StringField.
+func (f StringField) Left(n int) StringField {
+	return stringFunction("LEFT(?, ?)", f, n)
+}
+
+// Right returns a 'RIGHT(X, n)' StringField.
+func (f StringField) Right(n int) StringField {
+	return stringFunction("RIGHT(?, ?)", f, n)
+}
+
+// Position returns a 'POSITION(substring IN X)' NumberField. The position is
+// 1-indexed, and is 0 if the substring is not found.
+func (f StringField) Position(substring string) NumberField {
+	return numberFunction("POSITION(? IN ?)", substring, f)
+}
+
+// LPad returns an 'LPAD(X, length, fill)' StringField.
+func (f StringField) LPad(length int, fill string) StringField {
+	return stringFunction("LPAD(?, ?, ?)", f, length, fill)
+}
+
+// RPad returns an 'RPAD(X, length, fill)' StringField.
+func (f StringField) RPad(length int, fill string) StringField {
+	return stringFunction("RPAD(?, ?, ?)", f, length, fill)
+}
+
+// RegexpReplace returns a 'REGEXP_REPLACE(X, pattern, replacement)'
+// StringField. Only the first match is replaced, use RegexpReplaceFlags with
+// the 'g' flag to replace all matches.
+func (f StringField) RegexpReplace(pattern, replacement string) StringField {
+	return stringFunction("REGEXP_REPLACE(?, ?, ?)", f, pattern, replacement)
+}
+
+// RegexpReplaceFlags returns a 'REGEXP_REPLACE(X, pattern, replacement,
+// flags)' StringField.
+func (f StringField) RegexpReplaceFlags(pattern, replacement, flags string) StringField {
+	return stringFunction("REGEXP_REPLACE(?, ?, ?, ?)", f, pattern, replacement, flags)
+}
+
+// RegexpSubstring returns a 'SUBSTRING(X FROM pattern)' StringField. It
+// evaluates to the part of X that matches the pattern (or the first
+// parenthesized subexpression if there is one), or NULL if there is no match.
+func (f StringField) RegexpSubstring(pattern string) StringField {
+	return stringFunction("SUBSTRING(? FROM ?)", f, pattern)
+}
+
+// RegexpMatch returns a 'REGEXP_MATCH(X, pattern)' ArrayField. It evaluates to
+// a text array of the captured substrings of the first match, or NULL if there
+// is no match.
+func (f StringField) RegexpMatch(pattern string) ArrayField {
+	format := "REGEXP_MATCH(?, ?)"
+	return ArrayField{
+		format: &format,
+		values: []interface{}{f, pattern},
+	}
+}
+
+// Collate returns an 'X COLLATE "name"' StringField. The collation name is
+// quoted as an identifier, so it is case sensitive.
+func (f StringField) Collate(name string) StringField {
+	collation := FieldLiteral(`"` + strings.ReplaceAll(name, `"`, `""`) + `"`)
+	return stringExpression("? COLLATE ?", f, collation)
+}
+
+// InQuery returns an 'X IN (subquery)' Predicate. The subquery must select
+// exactly one field.
+func (f StringField) InQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? IN (?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
+// NotInQuery returns an 'X NOT IN (subquery)' Predicate. The subquery must
+// select exactly one field.
+func (f StringField) NotInQuery(query SelectQuery) Predicate {
+	return CustomPredicate{
+		Format: "? NOT IN (?)",
+		Values: []interface{}{f, subquery{query: query}},
+	}
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a StringField.
 func (f StringField) String() string {
@@ -299,3 +299,91 @@ func TestStringField_Predicates(t *testing.T) {
 		})
 	}
 }
+
+func TestStringField_Functions(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	name := NewStringField("name", &TableInfo{Schema: "public", Name: "users"})
+	email := NewStringField("email", &TableInfo{Schema: "public", Name: "users"})
+	tests := []TT{
+		{"Lower", Lower(email), nil, "LOWER(users.email)", nil},
+		{"Upper", email.Upper(), nil, "UPPER(users.email)", nil},
+		{"Trim", name.Trim(), nil, "TRIM(users.name)", nil},
+		{"LTrim", name.LTrim(), nil, "LTRIM(users.name)", nil},
+		{"RTrim", name.RTrim(), nil, "RTRIM(users.name)", nil},
+		{"Length", name.Length(), nil, "CHAR_LENGTH(users.name)", nil},
+		{"Substring", name.Substring(2, 3), nil, "SUBSTRING(users.name FROM ? FOR ?)", []interface{}{2, 3}},
+		{"Concat", Concat(name, String(" "), email), nil, "users.name || ? || users.email", []interface{}{" "}},
+		{"Replace", name.Replace("a", "b"), nil, "REPLACE(users.name, ?, ?)", []interface{}{"a", "b"}},
+		{"Left", name.Left(3), nil, "LEFT(users.name, ?)", []interface{}{3}},
+		{"Right", name.Right(3), nil, "RIGHT(users.name, ?)", []interface{}{3}},
+		{"Position", email.Position("@"), nil, "POSITION(? IN users.email)", []interface{}{"@"}},
+		{"LPad", name.LPad(8, "*"), nil, "LPAD(users.name, ?, ?)", []interface{}{8, "*"}},
+		{"RPad", name.RPad(8, "*"), nil, "RPAD(users.name, ?, ?)", []interface{}{8, "*"}},
+		{"Collate", name.Concat(email).Collate("C"), nil, `(users.name || users.email) COLLATE "C"`, nil},
+		{"excludedTableQualifiers", email.Lower(), []string{"users"}, "LOWER(email)", nil},
+		{"predicate", email.Lower().EqString("bob@email.com"), nil, "LOWER(users.email) = ?", []interface{}{"bob@email.com"}},
+		{"ORDER BY", email.Lower().Desc(), nil, "LOWER(users.email) DESC", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestStringField_Regexp(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	email := NewStringField("email", &TableInfo{Schema: "public", Name: "users"})
+	tests := []TT{
+		{"Matches", email.Matches("^bob"), "users.email ~ ?", []interface{}{"^bob"}},
+		{"MatchesInsensitive", email.MatchesInsensitive("^bob"), "users.email ~* ?", []interface{}{"^bob"}},
+		{"NotMatches", email.NotMatches("^bob"), "users.email !~ ?", []interface{}{"^bob"}},
+		{"NotMatchesInsensitive", email.NotMatchesInsensitive("^bob"), "users.email !~* ?", []interface{}{"^bob"}},
+		{"SimilarTo", email.SimilarTo("%(b|d)%"), "users.email SIMILAR TO ?", []interface{}{"%(b|d)%"}},
+		{"NotSimilarTo", email.NotSimilarTo("%(b|d)%"), "users.email NOT SIMILAR TO ?", []interface{}{"%(b|d)%"}},
+		{
+			"RegexpReplace",
+			email.RegexpReplace("@.*$", ""),
+			"REGEXP_REPLACE(users.email, ?, ?)",
+			[]interface{}{"@.*$", ""},
+		},
+		{
+			"RegexpReplaceFlags",
+			email.RegexpReplaceFlags("o", "0", "g"),
+			"REGEXP_REPLACE(users.email, ?, ?, ?)",
+			[]interface{}{"o", "0", "g"},
+		},
+		{"RegexpSubstring", email.RegexpSubstring("@(.*)$"), "SUBSTRING(users.email FROM ?)", []interface{}{"@(.*)$"}},
+		{"RegexpMatch", email.RegexpMatch("(.*)@(.*)"), "REGEXP_MATCH(users.email, ?)", []interface{}{"(.*)@(.*)"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
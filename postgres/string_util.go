@@ -2,9 +2,8 @@ package sq
 
 import (
 	"database/sql"
-	"database/sql/driver"
-	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"reflect"
 	"strconv"
@@ -15,15 +14,22 @@ import (
 // ExpandValues will expand each value one by one into successive question mark
 // ? placeholders in the format string, writing the results into the buffer and
 // args slice. It propagates the excludedTableQualifiers down to its child elements.
+// Question marks inside string literals, quoted identifiers and comments are
+// not placeholders, and ?? is an escaped question mark (see nextPlaceholder).
+// If the number of placeholders does not match the number of values, a
+// BuildError is appended to the args instead.
 func ExpandValues(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string, format string, values []interface{}) {
-	for i := strings.Index(format, "?"); i >= 0 && len(values) > 0; i = strings.Index(format, "?") {
+	if count := countPlaceholders(format); count != len(values) {
+		appendBuildError(buf, args, fmt.Errorf("format %q has %d placeholders, but %d values were given", format, count, len(values)))
+		return
+	}
+	for i, escaped := nextPlaceholder(format); i >= 0; i, escaped = nextPlaceholder(format) {
+		if escaped {
+			buf.WriteString(format[:i+2])
+			format = format[i+2:]
+			continue
+		}
 		buf.WriteString(format[:i])
-		// TODO: I don't know if ?? should be unescaped to ?
-		// if len(format[i:]) > 1 && format[i:i+2] == "??" {
-		// 	buf.WriteString("?")
-		// 	format = format[i+2:]
-		// 	continue
-		// }
 		AppendSQLValue(buf, args, excludedTableQualifiers, values[0])
 		format = format[i+1:]
 		values = values[1:]
@@ -96,49 +102,16 @@ func RandomString(n int) string {
 	return sb.String()
 }
 
-// TODO: write a version that takes in a buffer and writes into it instead
+// InterpolateSQLValue returns the SQL literal of an interface value, escaped
+// for Postgres. Prefer bind parameters over interpolated values when running
+// queries against a database. If the value cannot be written as a literal
+// (e.g. its driver.Valuer fails), a ? placeholder is returned instead.
 func InterpolateSQLValue(arg interface{}) string {
-	var str string // str is the SQL string representation of arg
-	switch v := arg.(type) {
-	case nil:
-		str = "NULL"
-	case bool:
-		if v {
-			str = "TRUE"
-		} else {
-			str = "FALSE"
-		}
-	case string:
-		str = "'" + v + "'"
-	case int, int8, int16, int32, int64,
-		uint, uint8, uint16, uint32, uint64,
-		float32, float64:
-		str = fmt.Sprint(arg)
-	case time.Time:
-		str = "'" + v.Format(time.RFC3339Nano) + "'"
-	case driver.Valuer:
-		Interface, err := v.Value()
-		if err != nil {
-			str = "(" + err.Error() + ")"
-		} else {
-			switch Concrete := Interface.(type) {
-			case string:
-				str = "'" + Concrete + "'"
-			case nil:
-				str = "NULL"
-			default:
-				str = "(" + fmt.Sprintf("%#v", arg) + ")" // give up, don't know what it is, resort to fmt.Sprintf
-			}
-		}
-	default:
-		b, err := json.Marshal(arg)
-		if err != nil {
-			str = "(" + fmt.Sprintf("%#v", arg) + ")" // give up, don't know what it is, resort to fmt.Sprintf
-		} else {
-			str = "'" + string(b) + "'"
-		}
+	s, err := literal(arg)
+	if err != nil {
+		return "?"
 	}
-	return str
+	return s
 }
 
 // AppendSQLRowResult
@@ -188,64 +161,116 @@ func AppendSQLDisplay(arg interface{}) string {
 }
 
 // QuestionToDollarPlaceholders will replace all MySQL style ? with Postgres
-// style incrementing placeholders i.e. $1, $2, $3 etc. To escape a literal
-// question mark ? , use two question marks ?? instead.
+// style incrementing placeholders i.e. $1, $2, $3 etc. Question marks inside
+// string literals, quoted identifiers and comments are left alone, as are the
+// jsonb ?| and ?& operators. To escape a literal question mark ? (e.g. for the
+// jsonb ? operator), use two question marks ?? instead.
 func QuestionToDollarPlaceholders(buf *strings.Builder, query string) {
-	i := 0
-	for {
-		p := strings.Index(query, "?")
-		if p < 0 {
-			break
-		}
-		buf.WriteString(query[:p])
-		if len(query[p:]) > 1 && query[p:p+2] == "??" {
+	n := 0
+	for i, escaped := nextPlaceholder(query); i >= 0; i, escaped = nextPlaceholder(query) {
+		buf.WriteString(query[:i])
+		if escaped {
 			buf.WriteString("?")
-			query = query[p+2:]
-		} else {
-			i++
-			buf.WriteString("$" + strconv.Itoa(i))
-			query = query[p+1:]
+			query = query[i+2:]
+			continue
 		}
+		n++
+		buf.WriteString("$" + strconv.Itoa(n))
+		query = query[i+1:]
 	}
 	buf.WriteString(query)
 }
 
 // QuestionInterpolate interpolates the question mark ? placeholders in a query
-// string with the args in the args slice. It is vulnerable to SQL injection
-// and should be used for display purposes only, not for actually running
-// against a database.
+// string with the SQL literals of the args in the args slice (see
+// InterpolateSQLValue). Placeholders whose arg cannot be written as a literal
+// are left as they are.
 func QuestionInterpolate(query string, args ...interface{}) string {
+	query, _ = questionInterpolate(query, args)
+	return query
+}
+
+// questionInterpolate is QuestionInterpolate, but it also returns the first
+// error from writing an arg as a literal.
+func questionInterpolate(query string, args []interface{}) (string, error) {
 	buf := &strings.Builder{}
+	var firstErr error
 	// i is the position of the ? in the query
-	for i := strings.Index(query, "?"); i >= 0 && len(args) > 0; i = strings.Index(query, "?") {
+	for i, escaped := nextPlaceholder(query); i >= 0; i, escaped = nextPlaceholder(query) {
+		if !escaped && len(args) == 0 {
+			break
+		}
 		buf.WriteString(query[:i])
-		if len(query[i:]) > 1 && query[i:i+2] == "??" {
+		if escaped {
 			buf.WriteString("?")
 			query = query[i+2:]
 			continue
 		}
-		buf.WriteString(InterpolateSQLValue(args[0]))
+		s, err := literal(args[0])
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			s = "?"
+		}
+		buf.WriteString(s)
 		query = query[i+1:]
 		args = args[1:]
 	}
 	buf.WriteString(query)
-	return buf.String()
+	return buf.String(), firstErr
 }
 
 // DollarInterpolate interpolates the dollar $1 ($2, $3 etc) placeholders in a
-// query string with the args in the args slice. It is vulnerable to SQL
-// injection and should be used for display purposes only, not for actually
-// running against a database.
+// query string with the SQL literals of the args in the args slice (see
+// InterpolateSQLValue). Placeholders without a corresponding arg, or whose arg
+// cannot be written as a literal, are left as they are.
 func DollarInterpolate(query string, args ...interface{}) string {
-	oldnewSets := make(map[int][]string)
-	for i, arg := range args {
-		str := InterpolateSQLValue(arg)
-		placeholder := "$" + strconv.Itoa(i+1)
-		oldnewSets[len(placeholder)] = append(oldnewSets[len(placeholder)], placeholder, str)
+	query, _ = dollarInterpolate(query, args)
+	return query
+}
+
+// dollarInterpolate is DollarInterpolate, but it also returns the first error
+// from writing an arg as a literal.
+func dollarInterpolate(query string, args []interface{}) (string, error) {
+	buf := &strings.Builder{}
+	var firstErr error
+	for i, length := nextDollarPlaceholder(query); i >= 0; i, length = nextDollarPlaceholder(query) {
+		buf.WriteString(query[:i])
+		placeholder := query[i : i+length]
+		n, _ := strconv.Atoi(placeholder[1:])
+		if n >= 1 && n <= len(args) {
+			s, err := literal(args[n-1])
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				s = placeholder
+			}
+			buf.WriteString(s)
+		} else {
+			buf.WriteString(placeholder)
+		}
+		query = query[i+length:]
 	}
-	result := query
-	for i := len(oldnewSets) + 1; i >= 2; i-- {
-		result = strings.NewReplacer(oldnewSets[i]...).Replace(result)
+	buf.WriteString(query)
+	return buf.String(), firstErr
+}
+
+// hashName returns the prefix followed by a hash of the SQL and its args. It
+// is used to name things that the user has not named, so that the name stays
+// the same every time the SQL is generated. The args are hashed as literals,
+// so that SQL which differs only in its bound values gets a different name.
+func hashName(prefix string, query string, args []interface{}) string {
+	h := fnv.New32a()
+	h.Write([]byte(query))
+	for _, arg := range args {
+		s, err := literal(arg)
+		if err != nil {
+			s = fmt.Sprintf("%#v", arg)
+		}
+		h.Write([]byte{0})
+		h.Write([]byte(s))
 	}
-	return result
+	return prefix + strconv.FormatUint(uint64(h.Sum32()), 36)
 }
@@ -0,0 +1,145 @@
+package sq
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestInterpolateSQLValue(t *testing.T) {
+	type TT struct {
+		description string
+		value       interface{}
+		want        string
+	}
+	tests := []TT{
+		{"nil", nil, "NULL"},
+		{"true", true, "TRUE"},
+		{"false", false, "FALSE"},
+		{"string", "lorem ipsum", "'lorem ipsum'"},
+		{"quotes", "it's", "'it''s'"},
+		{"backslash", `C:\it's`, `E'C:\\it''s'`},
+		{"control characters", "a\nb\tc\x01", `E'a\nb\tc\x01'`},
+		{"int", 33, "33"},
+		{"uint8", uint8(7), "7"},
+		{"negative int", -5, "(-5)"},
+		{"negative float", -1.5, "(-1.5)"},
+		{"float", 1.5, "1.5"},
+		{"NaN", math.NaN(), "'NaN'::DOUBLE PRECISION"},
+		{"time", time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.FixedZone("", -5*60*60)), "'2020-01-02 03:04:05.6-05:00'"},
+		{"time UTC", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), "'2020-01-02 03:04:05+00:00'"},
+		{"bytes", []byte{0xde, 0xad, 0xbe, 0xef}, `E'\\xdeadbeef'::BYTEA`},
+		{"nil bytes", []byte(nil), "NULL"},
+		{"int array", []int{1, 2, 3}, "ARRAY[1, 2, 3]"},
+		{"string array", []string{"a", "b'c"}, "ARRAY['a', 'b''c']"},
+		{"empty array", []string{}, "'{}'"},
+		{"driver.Valuer", sql.NullString{String: "a", Valid: true}, "'a'"},
+		{"driver.Valuer NULL", sql.NullInt64{}, "NULL"},
+		{"pointer", func() *string { s := "a"; return &s }(), "'a'"},
+		{"nil pointer", (*string)(nil), "NULL"},
+		{"json.RawMessage", json.RawMessage(`{"a":1}`), `'{"a":1}'`},
+		{"jsonable", map[string]string{"it's": "x"}, `'{"it''s":"x"}'`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			is.Equal(tt.want, InterpolateSQLValue(tt.value))
+		})
+	}
+}
+
+func TestDollarInterpolate(t *testing.T) {
+	is := is.New(t)
+	is.Equal(
+		"SELECT 'a', '$1', $$ $2 $$ WHERE x = 10 AND y = 3",
+		DollarInterpolate("SELECT $1, '$1', $$ $2 $$ WHERE x = $10 AND y = $3", "a", 2, 3, 4, 5, 6, 7, 8, 9, 10),
+	)
+}
+
+func TestToSQLInline(t *testing.T) {
+	type TT struct {
+		description string
+		q           interface{ ToSQLInline() (string, error) }
+		want        string
+	}
+	u := USERS().As("u")
+	tests := []TT{
+		{
+			"SelectQuery",
+			Select(u.USER_ID).From(u).Where(u.DISPLAYNAME.EqString("it's"), u.USER_ID.GtInt(5)),
+			"SELECT u.user_id FROM public.users AS u WHERE u.displayname = 'it''s' AND u.user_id > 5",
+		},
+		{
+			"InsertQuery",
+			InsertInto(u).Columns(u.DISPLAYNAME, u.EMAIL).Values("a", "a@email.com"),
+			"INSERT INTO public.users AS u (displayname, email) VALUES ('a', 'a@email.com')",
+		},
+		{
+			"UpdateQuery",
+			Update(u).Set(u.DISPLAYNAME.SetString("b")).Where(u.USER_ID.EqInt(1)),
+			"UPDATE public.users AS u SET displayname = 'b' WHERE u.user_id = 1",
+		},
+		{
+			"DeleteQuery",
+			DeleteFrom(u).Where(u.USER_ID.EqInt(1)),
+			"DELETE FROM public.users AS u WHERE u.user_id = 1",
+		},
+		{
+			"VariadicQuery",
+			Union(Select(u.USER_ID).From(u).Where(u.USER_ID.EqInt(1)), Select(u.USER_ID).From(u).Where(u.USER_ID.EqInt(2))),
+			"SELECT u.user_id FROM public.users AS u WHERE u.user_id = 1" +
+				" UNION SELECT u.user_id FROM public.users AS u WHERE u.user_id = 2",
+		},
+		{
+			"negated negative value",
+			Select(Int(-5).Neg()).From(u).Where(u.USER_ID.GtInt(-1)),
+			"SELECT -(-5) FROM public.users AS u WHERE u.user_id > (-1)",
+		},
+		{
+			"CustomQuery",
+			Queryf("CREATE VIEW v AS SELECT ? AS x, '?' AS y", "a"),
+			"CREATE VIEW v AS SELECT 'a' AS x, '?' AS y",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			got, err := tt.q.ToSQLInline()
+			is.NoErr(err)
+			is.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestToSQLInline_Error(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	errValue := errors.New("cannot be a value")
+	_, err := Select(u.USER_ID).From(u).Where(Predicatef("? = ?", u.EMAIL, errValuer{errValue})).ToSQLInline()
+	is.True(errors.Is(err, errValue))
+	_, err = Queryf("SELECT ?", errValuer{errValue}).ToSQLInline()
+	is.True(errors.Is(err, errValue))
+	var berr *BuildError
+	_, err = Select(u.USER_ID).From(u).Where(Predicatef("? = ?", u.EMAIL)).ToSQLInline()
+	is.True(errors.As(err, &berr))
+	is.Equal("SELECT u.user_id FROM public.users AS u WHERE ? = 'a'", QuestionInterpolate("SELECT u.user_id FROM public.users AS u WHERE ? = ?", errValuer{errValue}, "a"))
+}
+
+// errValuer is a driver.Valuer that always fails.
+type errValuer struct {
+	err error
+}
+
+func (v errValuer) Value() (driver.Value, error) {
+	return nil, v.err
+}
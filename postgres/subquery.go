@@ -0,0 +1,53 @@
+package sq
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// subquery is a SelectQuery used as an operand of an expression, e.g. in
+// 'X IN (subquery)' or as a scalar subquery. Such subqueries must select
+// exactly one field, which is checked when the SQL is built: if the check
+// fails, a BuildError is written into the query in place of the subquery.
+type subquery struct {
+	query SelectQuery
+}
+
+// AppendSQLExclude marshals the subquery into a buffer and an args slice. It
+// does not bracket the subquery, that is left to the format it is used in.
+func (s subquery) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
+	switch {
+	case len(s.query.SelectFields) != 1:
+		appendBuildError(buf, args, fmt.Errorf("invalid subquery: expected exactly 1 field in the select list, got %d", len(s.query.SelectFields)))
+	case s.query.SelectFields[0] == FieldLiteral("*"):
+		appendBuildError(buf, args, errors.New("invalid subquery: expected exactly 1 field in the select list, got *"))
+	default:
+		s.query.NestThis().AppendSQL(buf, args)
+	}
+}
+
+// ScalarNumber returns the SelectQuery as a scalar subquery NumberField i.e.
+// '(SELECT ...)', so that it can be used in the SELECT list, SET clause or in
+// comparisons. The SelectQuery must select exactly one field.
+func (q SelectQuery) ScalarNumber() NumberField {
+	return numberFunction("(?)", subquery{query: q})
+}
+
+// ScalarString returns the SelectQuery as a scalar subquery StringField i.e.
+// '(SELECT ...)', so that it can be used in the SELECT list, SET clause or in
+// comparisons. The SelectQuery must select exactly one field.
+func (q SelectQuery) ScalarString() StringField {
+	return stringFunction("(?)", subquery{query: q})
+}
+
+// ScalarTime returns the SelectQuery as a scalar subquery TimeField i.e.
+// '(SELECT ...)', so that it can be used in the SELECT list, SET clause or in
+// comparisons. The SelectQuery must select exactly one field.
+func (q SelectQuery) ScalarTime() TimeField {
+	format := "(?)"
+	return TimeField{
+		format: &format,
+		values: []interface{}{subquery{query: q}},
+	}
+}
@@ -0,0 +1,91 @@
+package sq
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSubquery(t *testing.T) {
+	type TT struct {
+		description string
+		q           Query
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u, ur := USERS().As("u"), USER_ROLES().As("ur")
+	roleUsers := From(ur).Where(ur.ROLE.EqString("lead")).Select(ur.USER_ID)
+	tests := []TT{
+		{
+			"InQuery",
+			From(u).Where(u.USER_ID.InQuery(roleUsers)).Select(u.EMAIL),
+			"SELECT u.email FROM public.users AS u" +
+				" WHERE u.user_id IN (SELECT ur.user_id FROM public.user_roles AS ur WHERE ur.role = $1)",
+			[]interface{}{"lead"},
+		},
+		{
+			"NotInQuery",
+			From(u).Where(u.USER_ID.NotInQuery(roleUsers)).Select(u.EMAIL),
+			"SELECT u.email FROM public.users AS u" +
+				" WHERE u.user_id NOT IN (SELECT ur.user_id FROM public.user_roles AS ur WHERE ur.role = $1)",
+			[]interface{}{"lead"},
+		},
+		{
+			"EqAnyQuery",
+			From(u).Where(u.USER_ID.EqAnyQuery(roleUsers)).Select(u.EMAIL),
+			"SELECT u.email FROM public.users AS u" +
+				" WHERE u.user_id = ANY(SELECT ur.user_id FROM public.user_roles AS ur WHERE ur.role = $1)",
+			[]interface{}{"lead"},
+		},
+		{
+			"GtAll array",
+			From(u).Where(u.USER_ID.GtAll(Array([]int{1, 2}))).Select(u.EMAIL),
+			"SELECT u.email FROM public.users AS u WHERE u.user_id > ALL(ARRAY[$1, $2])",
+			[]interface{}{1, 2},
+		},
+		{
+			"scalar subqueries in SELECT",
+			From(u).Select(
+				u.EMAIL,
+				From(ur).Where(ur.USER_ID.Eq(u.USER_ID)).Select(Count()).ScalarNumber().As("role_count"),
+				From(ur).Where(ur.USER_ID.Eq(u.USER_ID)).Select(Max(ur.CREATED_AT)).ScalarTime().As("last_role_at"),
+			),
+			"SELECT u.email" +
+				", (SELECT COUNT(*) FROM public.user_roles AS ur WHERE ur.user_id = u.user_id) AS role_count" +
+				", (SELECT MAX(ur.created_at) FROM public.user_roles AS ur WHERE ur.user_id = u.user_id) AS last_role_at" +
+				" FROM public.users AS u",
+			nil,
+		},
+		{
+			"scalar subquery in SET",
+			Update(u).Set(u.DISPLAYNAME.Set(From(ur).Where(ur.USER_ID.Eq(u.USER_ID)).Select(ur.ROLE).Limit(1).ScalarString())),
+			"UPDATE public.users AS u" +
+				" SET displayname = (SELECT ur.role FROM public.user_roles AS ur WHERE ur.user_id = u.user_id LIMIT $1)",
+			[]interface{}{int64(1)},
+		},
+		{
+			"more than one field",
+			From(u).Where(u.USER_ID.InQuery(From(ur).Select(ur.USER_ID, ur.ROLE))).Select(u.EMAIL),
+			"SELECT u.email FROM public.users AS u" +
+				" WHERE u.user_id IN ($1)",
+			[]interface{}{&BuildError{Err: errors.New("invalid subquery: expected exactly 1 field in the select list, got 2")}},
+		},
+		{
+			"SELECT *",
+			From(u).Select(From(ur).SelectAll().ScalarNumber()),
+			"SELECT ($1) FROM public.users AS u",
+			[]interface{}{&BuildError{Err: errors.New("invalid subquery: expected exactly 1 field in the select list, got *")}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
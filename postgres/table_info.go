@@ -9,6 +9,10 @@ type TableInfo struct {
 	Schema string
 	Name   string
 	Alias  string
+	// CaseSensitive marks the Schema, Name and column names as the exact
+	// names in the database, so that they are quoted if they would otherwise
+	// not be read back as the same names. It is set by sqgen.
+	CaseSensitive bool
 }
 
 // AppendSQL adds the fully qualified table name into the buffer.
@@ -17,22 +21,10 @@ func (tbl *TableInfo) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 		return
 	}
 	if tbl.Schema != "" {
-		if strings.ContainsAny(tbl.Schema, " \t") {
-			buf.WriteString("`")
-			buf.WriteString(tbl.Schema)
-			buf.WriteString("`.")
-		} else {
-			buf.WriteString(tbl.Schema)
-			buf.WriteString(".")
-		}
-	}
-	if strings.ContainsAny(tbl.Name, " \t") {
-		buf.WriteString("`")
-		buf.WriteString(tbl.Name)
-		buf.WriteString("`")
-	} else {
-		buf.WriteString(tbl.Name)
+		appendIdentifier(buf, tbl.Schema, tbl.CaseSensitive)
+		buf.WriteString(".")
 	}
+	appendIdentifier(buf, tbl.Name, tbl.CaseSensitive)
 }
 
 // GetAlias implements the Table interface. It returns the alias from the
@@ -53,5 +45,10 @@ func (tbl *TableInfo) GetName() string {
 	return tbl.Name
 }
 
+// isCaseSensitive reports whether the TableInfo names are case sensitive.
+func (tbl *TableInfo) isCaseSensitive() bool {
+	return tbl != nil && tbl.CaseSensitive
+}
+
 // AssertBaseTable implements the BaseTable interface.
 func (tbl *TableInfo) AssertBaseTable() {}
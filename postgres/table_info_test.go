@@ -18,12 +18,15 @@ func TestTableInfo_AppendSQL(t *testing.T) {
 		{"empty", nil, "", nil},
 		{"has schema", &TableInfo{Schema: "public", Name: "users"}, "public.users", nil},
 		{"no schema", &TableInfo{Name: "users"}, "users", nil},
+		{"reserved word", &TableInfo{Schema: "public", Name: "user"}, `public."user"`, nil},
+		{"mixed case", &TableInfo{Schema: "public", Name: "UserRoles"}, "public.UserRoles", nil},
+		{"case sensitive", &TableInfo{Schema: "Public", Name: "UserRoles", CaseSensitive: true}, `"Public"."UserRoles"`, nil},
 		{
 			// https://stackoverflow.com/q/506826
 			// only villians put whitespaces in their schema/table/column names >.>
 			"quoted whitespace",
 			&TableInfo{Schema: "student registration", Name: "table with whitespace"},
-			"`student registration`.`table with whitespace`",
+			`"student registration"."table with whitespace"`,
 			nil,
 		},
 	}
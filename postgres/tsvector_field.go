@@ -0,0 +1,247 @@
+package sq
+
+import "strings"
+
+// TSVectorField either represents a tsvector column or a tsvector expression.
+type TSVectorField struct {
+	// TSVectorField will be one of the following:
+
+	// 1) tsvector expression
+	// Examples of tsvector expressions:
+	// | query                                    | args    |
+	// |------------------------------------------|---------|
+	// | TO_TSVECTOR(?::regconfig, products.name) | english |
+	// | TO_TSVECTOR(products.name)               |         |
+	format *string
+	values []interface{}
+
+	// 2) tsvector column
+	// Examples of tsvector columns:
+	// | query                  | args |
+	// |------------------------|------|
+	// | products.search_vector |      |
+	// | search_vector          |      |
+	alias string
+	table Table
+	name  string
+}
+
+// AppendSQLExclude marshals the TSVectorField into a buffer and an args slice.
+// It will not table qualify itself if its table qualifer appears in the
+// excludedTableQualifiers list.
+func (f TSVectorField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
+	switch {
+	case f.format != nil:
+		// 1) tsvector expression
+		ExpandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
+	default:
+		// 2) tsvector column
+		appendColumn(buf, f.table, f.name, excludedTableQualifiers)
+	}
+}
+
+// NewTSVectorField returns a new TSVectorField representing a tsvector column.
+func NewTSVectorField(name string, table Table) TSVectorField {
+	return TSVectorField{
+		name:  name,
+		table: table,
+	}
+}
+
+// ToTSVector returns a new TSVectorField representing the
+// 'TO_TSVECTOR(config, document)' expression. If config is an empty string,
+// the database's default_text_search_config is used.
+func ToTSVector(config string, document Field) TSVectorField {
+	if config == "" {
+		format := "TO_TSVECTOR(?)"
+		return TSVectorField{
+			format: &format,
+			values: []interface{}{document},
+		}
+	}
+	format := "TO_TSVECTOR(?::regconfig, ?)"
+	return TSVectorField{
+		format: &format,
+		values: []interface{}{config, document},
+	}
+}
+
+// Set returns a FieldAssignment associating the TSVectorField to the value
+// i.e. 'field = value'.
+func (f TSVectorField) Set(value interface{}) FieldAssignment {
+	return FieldAssignment{
+		Field: f,
+		Value: value,
+	}
+}
+
+// As returns a new TSVectorField with the new field Alias i.e. 'field AS
+// Alias'.
+func (f TSVectorField) As(alias string) TSVectorField {
+	f.alias = alias
+	return f
+}
+
+// IsNull returns an 'X IS NULL' Predicate.
+func (f TSVectorField) IsNull() Predicate {
+	return CustomPredicate{
+		Format: "? IS NULL",
+		Values: []interface{}{f},
+	}
+}
+
+// IsNotNull returns an 'X IS NOT NULL' Predicate.
+func (f TSVectorField) IsNotNull() Predicate {
+	return CustomPredicate{
+		Format: "? IS NOT NULL",
+		Values: []interface{}{f},
+	}
+}
+
+// Matches returns an 'X @@ Y' Predicate, which checks if the tsvector matches
+// the tsquery.
+func (f TSVectorField) Matches(query TSQueryField) Predicate {
+	return CustomPredicate{
+		Format: "? @@ ?",
+		Values: []interface{}{f, query},
+	}
+}
+
+// String implements the fmt.Stringer interface. It returns the string
+// representation of a TSVectorField.
+func (f TSVectorField) String() string {
+	buf := &strings.Builder{}
+	var args []interface{}
+	f.AppendSQLExclude(buf, &args, nil)
+	return QuestionInterpolate(buf.String(), args...)
+}
+
+// GetAlias implements the Field interface. It returns the Alias of the
+// TSVectorField.
+func (f TSVectorField) GetAlias() string {
+	return f.alias
+}
+
+// GetName implements the Field interface. It returns the Name of the
+// TSVectorField.
+func (f TSVectorField) GetName() string {
+	return f.name
+}
+
+// TSQueryField represents a tsquery expression. The query text is always
+// bound as an argument.
+type TSQueryField struct {
+	alias  string
+	format string
+	values []interface{}
+}
+
+// AppendSQLExclude marshals the TSQueryField into a buffer and an args slice.
+func (f TSQueryField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string) {
+	ExpandValues(buf, args, excludedTableQualifiers, f.format, f.values)
+}
+
+func tsquery(function, config, query string) TSQueryField {
+	if config == "" {
+		return TSQueryField{
+			format: function + "(?)",
+			values: []interface{}{query},
+		}
+	}
+	return TSQueryField{
+		format: function + "(?::regconfig, ?)",
+		values: []interface{}{config, query},
+	}
+}
+
+// ToTSQuery returns a new TSQueryField representing the 'TO_TSQUERY(config,
+// query)' expression. The query must be written in tsquery syntax e.g.
+// 'fat & (rat | cat)'. If config is an empty string, the database's
+// default_text_search_config is used.
+func ToTSQuery(config, query string) TSQueryField {
+	return tsquery("TO_TSQUERY", config, query)
+}
+
+// PlainToTSQuery returns a new TSQueryField representing the
+// 'PLAINTO_TSQUERY(config, query)' expression. The query is plain text, and
+// all of its words must match.
+func PlainToTSQuery(config, query string) TSQueryField {
+	return tsquery("PLAINTO_TSQUERY", config, query)
+}
+
+// PhraseToTSQuery returns a new TSQueryField representing the
+// 'PHRASETO_TSQUERY(config, query)' expression. The query is plain text, and
+// its words must match in order.
+func PhraseToTSQuery(config, query string) TSQueryField {
+	return tsquery("PHRASETO_TSQUERY", config, query)
+}
+
+// WebsearchToTSQuery returns a new TSQueryField representing the
+// 'WEBSEARCH_TO_TSQUERY(config, query)' expression. The query uses web search
+// engine syntax e.g. '"sad cat" or "fat rat" -mouse'. It requires Postgres 11
+// and above.
+func WebsearchToTSQuery(config, query string) TSQueryField {
+	return tsquery("WEBSEARCH_TO_TSQUERY", config, query)
+}
+
+// As returns a new TSQueryField with the new field Alias i.e. 'field AS
+// Alias'.
+func (f TSQueryField) As(alias string) TSQueryField {
+	f.alias = alias
+	return f
+}
+
+// String implements the fmt.Stringer interface. It returns the string
+// representation of a TSQueryField.
+func (f TSQueryField) String() string {
+	buf := &strings.Builder{}
+	var args []interface{}
+	f.AppendSQLExclude(buf, &args, nil)
+	return QuestionInterpolate(buf.String(), args...)
+}
+
+// GetAlias implements the Field interface. It returns the Alias of the
+// TSQueryField.
+func (f TSQueryField) GetAlias() string {
+	return f.alias
+}
+
+// GetName implements the Field interface. It returns the Name of the
+// TSQueryField, which is always an empty string.
+func (f TSQueryField) GetName() string {
+	return ""
+}
+
+// TSRank represents the TS_RANK(vector, query) function, which scores how
+// relevant the tsvector is to the tsquery.
+func TSRank(vector TSVectorField, query TSQueryField) NumberField {
+	return numberFunction("TS_RANK(?, ?)", vector, query)
+}
+
+// TSRankCD represents the TS_RANK_CD(vector, query) function, which scores how
+// relevant the tsvector is to the tsquery using cover density ranking.
+func TSRankCD(vector TSVectorField, query TSQueryField) NumberField {
+	return numberFunction("TS_RANK_CD(?, ?)", vector, query)
+}
+
+// TSHeadline represents the TS_HEADLINE(config, document, query, options)
+// function, which returns an excerpt from the document with the query terms
+// highlighted. If config is an empty string, the database's
+// default_text_search_config is used. If options is an empty string, the
+// default options are used.
+func TSHeadline(config string, document StringField, query TSQueryField, options string) StringField {
+	var format string
+	var values []interface{}
+	if config != "" {
+		format = "TS_HEADLINE(?::regconfig, ?, ?"
+		values = []interface{}{config, document, query}
+	} else {
+		format = "TS_HEADLINE(?, ?"
+		values = []interface{}{document, query}
+	}
+	if options != "" {
+		format += ", ?"
+		values = append(values, options)
+	}
+	return stringFunction(format+")", values...)
+}
@@ -0,0 +1,78 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestTSVectorField(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	products := &TableInfo{Schema: "public", Name: "products"}
+	vector := NewTSVectorField("search_vector", products)
+	name := NewStringField("name", products)
+	tests := []TT{
+		{"column", vector, nil, "products.search_vector", nil},
+		{"excludedTableQualifiers", vector, []string{"products"}, "search_vector", nil},
+		{"quoted whitespace", NewTSVectorField("search vector", products), nil, `products."search vector"`, nil},
+		{"ToTSVector", ToTSVector("english", name), nil, "TO_TSVECTOR(?::regconfig, products.name)", []interface{}{"english"}},
+		{"ToTSVector default config", ToTSVector("", name), nil, "TO_TSVECTOR(products.name)", nil},
+		{"ToTSQuery", ToTSQuery("english", "fat & rat"), nil, "TO_TSQUERY(?::regconfig, ?)", []interface{}{"english", "fat & rat"}},
+		{"PlainToTSQuery", PlainToTSQuery("", "fat rat"), nil, "PLAINTO_TSQUERY(?)", []interface{}{"fat rat"}},
+		{"PhraseToTSQuery", PhraseToTSQuery("", "fat rat"), nil, "PHRASETO_TSQUERY(?)", []interface{}{"fat rat"}},
+		{
+			"Matches",
+			vector.Matches(WebsearchToTSQuery("english", `"fat rat" -cat`)),
+			nil,
+			"products.search_vector @@ WEBSEARCH_TO_TSQUERY(?::regconfig, ?)",
+			[]interface{}{"english", `"fat rat" -cat`},
+		},
+		{
+			"TSRank",
+			TSRank(vector, PlainToTSQuery("", "rat")).Desc(),
+			nil,
+			"TS_RANK(products.search_vector, PLAINTO_TSQUERY(?)) DESC",
+			[]interface{}{"rat"},
+		},
+		{
+			"TSRankCD",
+			TSRankCD(vector, PlainToTSQuery("", "rat")),
+			nil,
+			"TS_RANK_CD(products.search_vector, PLAINTO_TSQUERY(?))",
+			[]interface{}{"rat"},
+		},
+		{
+			"TSHeadline",
+			TSHeadline("english", name, PlainToTSQuery("english", "rat"), "MaxWords=10"),
+			nil,
+			"TS_HEADLINE(?::regconfig, products.name, PLAINTO_TSQUERY(?::regconfig, ?), ?)",
+			[]interface{}{"english", "english", "rat", "MaxWords=10"},
+		},
+		{
+			"TSHeadline default config and options",
+			TSHeadline("", name, PlainToTSQuery("", "rat"), ""),
+			nil,
+			"TS_HEADLINE(products.name, PLAINTO_TSQUERY(?))",
+			[]interface{}{"rat"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package sq
+
+// StringArrayField is an ArrayField whose elements are strings e.g. a TEXT[]
+// or VARCHAR[] column. Element access returns a StringField.
+type StringArrayField struct {
+	ArrayField
+}
+
+// NewStringArrayField returns a new StringArrayField representing a string
+// array column.
+func NewStringArrayField(name string, table Table) StringArrayField {
+	return StringArrayField{
+		ArrayField: NewArrayField(name, table),
+	}
+}
+
+// StringArray returns a new StringArrayField representing a literal []string
+// value.
+func StringArray(slice []string) StringArrayField {
+	return StringArrayField{
+		ArrayField: Array(slice),
+	}
+}
+
+// At returns the array element at the given index i.e. 'X[index]'. Postgres
+// arrays are 1-indexed.
+func (f StringArrayField) At(index int) StringField {
+	return stringFunction("?[?]", f.subscriptable(), index)
+}
+
+// Slice returns the array slice from the lower index to the upper index
+// (inclusive) i.e. 'X[lower:upper]'.
+func (f StringArrayField) Slice(lower, upper int) StringArrayField {
+	return StringArrayField{ArrayField: f.ArrayField.Slice(lower, upper)}
+}
+
+// Append represents the ARRAY_APPEND(X, value) function.
+func (f StringArrayField) Append(value interface{}) StringArrayField {
+	return StringArrayField{ArrayField: f.ArrayField.Append(value)}
+}
+
+// Prepend represents the ARRAY_PREPEND(value, X) function.
+func (f StringArrayField) Prepend(value interface{}) StringArrayField {
+	return StringArrayField{ArrayField: f.ArrayField.Prepend(value)}
+}
+
+// Remove represents the ARRAY_REMOVE(X, value) function.
+func (f StringArrayField) Remove(value interface{}) StringArrayField {
+	return StringArrayField{ArrayField: f.ArrayField.Remove(value)}
+}
+
+// Set returns a FieldAssignment associating the StringArrayField to the value
+// i.e. 'field = value'. It accepts any array valued Field, including the
+// results of Slice, Append, Prepend and Remove.
+func (f StringArrayField) Set(value Field) FieldAssignment {
+	return FieldAssignment{
+		Field: f,
+		Value: value,
+	}
+}
+
+// As returns a new StringArrayField with the new field Alias i.e. 'field AS
+// Alias'.
+func (f StringArrayField) As(alias string) StringArrayField {
+	f.ArrayField = f.ArrayField.As(alias)
+	return f
+}
+
+// NumberArrayField is an ArrayField whose elements are numbers e.g. an INT[]
+// or NUMERIC[] column. Element access returns a NumberField.
+type NumberArrayField struct {
+	ArrayField
+}
+
+// NewNumberArrayField returns a new NumberArrayField representing a number
+// array column.
+func NewNumberArrayField(name string, table Table) NumberArrayField {
+	return NumberArrayField{
+		ArrayField: NewArrayField(name, table),
+	}
+}
+
+// NumberArray returns a new NumberArrayField representing a literal slice
+// value. Only []int, []int64 and []float64 slices are supported.
+func NumberArray(slice interface{}) NumberArrayField {
+	return NumberArrayField{
+		ArrayField: Array(slice),
+	}
+}
+
+// At returns the array element at the given index i.e. 'X[index]'. Postgres
+// arrays are 1-indexed.
+func (f NumberArrayField) At(index int) NumberField {
+	return numberFunction("?[?]", f.subscriptable(), index)
+}
+
+// Slice returns the array slice from the lower index to the upper index
+// (inclusive) i.e. 'X[lower:upper]'.
+func (f NumberArrayField) Slice(lower, upper int) NumberArrayField {
+	return NumberArrayField{ArrayField: f.ArrayField.Slice(lower, upper)}
+}
+
+// Append represents the ARRAY_APPEND(X, value) function.
+func (f NumberArrayField) Append(value interface{}) NumberArrayField {
+	return NumberArrayField{ArrayField: f.ArrayField.Append(value)}
+}
+
+// Prepend represents the ARRAY_PREPEND(value, X) function.
+func (f NumberArrayField) Prepend(value interface{}) NumberArrayField {
+	return NumberArrayField{ArrayField: f.ArrayField.Prepend(value)}
+}
+
+// Remove represents the ARRAY_REMOVE(X, value) function.
+func (f NumberArrayField) Remove(value interface{}) NumberArrayField {
+	return NumberArrayField{ArrayField: f.ArrayField.Remove(value)}
+}
+
+// Set returns a FieldAssignment associating the NumberArrayField to the value
+// i.e. 'field = value'. It accepts any array valued Field, including the
+// results of Slice, Append, Prepend and Remove.
+func (f NumberArrayField) Set(value Field) FieldAssignment {
+	return FieldAssignment{
+		Field: f,
+		Value: value,
+	}
+}
+
+// As returns a new NumberArrayField with the new field Alias i.e. 'field AS
+// Alias'.
+func (f NumberArrayField) As(alias string) NumberArrayField {
+	f.ArrayField = f.ArrayField.As(alias)
+	return f
+}
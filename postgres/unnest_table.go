@@ -0,0 +1,76 @@
+package sq
+
+import "strings"
+
+// UnnestTable represents the UNNEST(array) table function, which expands an
+// array into a set of rows. It can be used anywhere a Table is accepted e.g.
+// in FROM or JOIN. Because UNNEST returns a single column, Postgres names that
+// column after the table alias, so the element column is referenced as
+// 'alias.alias'.
+type UnnestTable struct {
+	Alias      string
+	Array      Field
+	Ordinality bool
+}
+
+// Unnest returns a new UnnestTable that expands the array. The table is
+// aliased 'unnest' by default, use As to give it a different alias when
+// unnesting more than one array in the same query.
+func Unnest(array Field) UnnestTable {
+	return UnnestTable{
+		Alias: "unnest",
+		Array: array,
+	}
+}
+
+// AppendSQL marshals the UnnestTable into a buffer and an args slice.
+func (t UnnestTable) AppendSQL(buf *strings.Builder, args *[]interface{}) {
+	ExpandValues(buf, args, nil, "UNNEST(?)", []interface{}{t.Array})
+	if t.Ordinality {
+		buf.WriteString(" WITH ORDINALITY")
+	}
+}
+
+// As returns a new UnnestTable with the new table Alias.
+func (t UnnestTable) As(alias string) UnnestTable {
+	t.Alias = alias
+	return t
+}
+
+// WithOrdinality returns a new UnnestTable that also returns the 1-indexed
+// position of each element i.e. 'UNNEST(array) WITH ORDINALITY'. The position
+// is accessed with Index.
+func (t UnnestTable) WithOrdinality() UnnestTable {
+	t.Ordinality = true
+	return t
+}
+
+// StringValue returns the element column of the UnnestTable as a
+// StringField.
+func (t UnnestTable) StringValue() StringField {
+	return NewStringField(t.Alias, t)
+}
+
+// NumberValue returns the element column of the UnnestTable as a
+// NumberField.
+func (t UnnestTable) NumberValue() NumberField {
+	return NewNumberField(t.Alias, t)
+}
+
+// Index returns the ordinality column of the UnnestTable. It is only
+// available if the UnnestTable was created WithOrdinality.
+func (t UnnestTable) Index() NumberField {
+	return NewNumberField("ordinality", t)
+}
+
+// GetAlias implements the Table interface. It returns the Alias of the
+// UnnestTable.
+func (t UnnestTable) GetAlias() string {
+	return t.Alias
+}
+
+// GetName implements the Table interface. It returns the name of the
+// UnnestTable, which is always an empty string.
+func (t UnnestTable) GetName() string {
+	return ""
+}
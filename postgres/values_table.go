@@ -0,0 +1,231 @@
+package sq
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ValuesTable represents a VALUES list that is used as a table i.e.
+// '(VALUES (a, b), (c, d)) AS alias (column1, column2)'. It can be used
+// anywhere a Table is accepted e.g. in FROM, JOIN or UPDATE ... FROM.
+//
+// Postgres types the parameters of a VALUES list as text unless told
+// otherwise, so the first row of the ValuesTable is cast to the column types.
+// The column types are inferred from the Go values of the rows (int -> BIGINT,
+// string -> TEXT etc), or can be set explicitly with Cast.
+type ValuesTable struct {
+	Alias     string
+	Columns   []string
+	Types     []string
+	RowValues RowValues
+}
+
+// NewValuesTable returns a new ValuesTable with the alias and column names.
+// Add rows to it with Row.
+func NewValuesTable(alias string, columns ...string) ValuesTable {
+	return ValuesTable{
+		Alias:   alias,
+		Columns: columns,
+	}
+}
+
+// AppendSQL marshals the ValuesTable into a buffer and an args slice. The
+// alias and column list are written by the ValuesTable itself because they
+// have to come after the VALUES list. A ValuesTable that is not a valid VALUES
+// list (see validate) is written as a BuildError.
+func (t ValuesTable) AppendSQL(buf *strings.Builder, args *[]interface{}) {
+	if err := t.validate(); err != nil {
+		appendBuildError(buf, args, err)
+		return
+	}
+	buf.WriteString("(VALUES ")
+	for i, rowvalue := range t.RowValues {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("(")
+		for j, value := range rowvalue {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			// only the first row needs to be cast, Postgres infers the types
+			// of the other rows from it
+			var typ string
+			if i == 0 {
+				typ = t.columnType(j)
+			}
+			appendValuesTableValue(buf, args, value, typ)
+		}
+		buf.WriteString(")")
+	}
+	buf.WriteString(") AS ")
+	appendIdentifier(buf, t.Alias, false)
+	if len(t.Columns) > 0 {
+		buf.WriteString(" (")
+		appendIdentifiers(buf, t.Columns)
+		buf.WriteString(")")
+	}
+}
+
+// appendValuesTableValue writes a value of a ValuesTable row into the buffer,
+// casting it to typ if typ is not empty.
+func appendValuesTableValue(buf *strings.Builder, args *[]interface{}, value interface{}, typ string) {
+	switch v := value.(type) {
+	case []byte:
+		// a []byte is a single BYTEA value, not a list of values
+		buf.WriteString("?")
+		*args = append(*args, v)
+	case interface {
+		AppendSQLExclude(*strings.Builder, *[]interface{}, []string)
+	}:
+		if typ == "" {
+			v.AppendSQLExclude(buf, args, nil)
+			return
+		}
+		buf.WriteString("CAST(")
+		v.AppendSQLExclude(buf, args, nil)
+		buf.WriteString(" AS " + typ + ")")
+		return
+	default:
+		AppendSQLValue(buf, args, nil, value)
+	}
+	if typ != "" {
+		buf.WriteString("::" + typ)
+	}
+}
+
+// columnType returns the type that the column at index i is cast to. It
+// returns an empty string if the column should not be cast.
+func (t ValuesTable) columnType(i int) string {
+	if i < len(t.Types) && t.Types[i] != "" {
+		return t.Types[i]
+	}
+	for _, rowvalue := range t.RowValues {
+		if i >= len(rowvalue) || rowvalue[i] == nil {
+			continue
+		}
+		return valueType(rowvalue[i])
+	}
+	return ""
+}
+
+// valueType returns the Postgres type of a Go value. It returns an empty string
+// for values whose type is already known to Postgres (i.e. Fields) or cannot be
+// inferred.
+func valueType(value interface{}) string {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, sql.NullInt64:
+		return "BIGINT"
+	case float32, float64, sql.NullFloat64:
+		return "DOUBLE PRECISION"
+	case string, sql.NullString:
+		return "TEXT"
+	case bool, sql.NullBool:
+		return "BOOLEAN"
+	case time.Time, sql.NullTime:
+		return "TIMESTAMPTZ"
+	case []byte:
+		return "BYTEA"
+	}
+	return ""
+}
+
+// validate checks that the ValuesTable has at least one row, that every row
+// has the same number of values as the first row and as there are Columns,
+// and that no value is a slice (which would expand into several values).
+func (t ValuesTable) validate() error {
+	if len(t.RowValues) == 0 {
+		return fmt.Errorf("ValuesTable %s has no rows", t.Alias)
+	}
+	for i, rowvalue := range t.RowValues {
+		switch {
+		case len(rowvalue) == 0:
+			return fmt.Errorf("row %d of ValuesTable %s has no values", i+1, t.Alias)
+		case len(t.Columns) > 0 && len(rowvalue) != len(t.Columns):
+			return fmt.Errorf("row %d of ValuesTable %s has %d values, but there are %d columns", i+1, t.Alias, len(rowvalue), len(t.Columns))
+		case len(rowvalue) != len(t.RowValues[0]):
+			return fmt.Errorf("row %d of ValuesTable %s has %d values, but row 1 has %d", i+1, t.Alias, len(rowvalue), len(t.RowValues[0]))
+		}
+		for j, value := range rowvalue {
+			if _, ok := value.([]byte); ok {
+				continue
+			}
+			if value != nil && reflect.TypeOf(value).Kind() == reflect.Slice {
+				return fmt.Errorf("value %d of row %d of ValuesTable %s is a slice (%T), which cannot be a single value", j+1, i+1, t.Alias, value)
+			}
+		}
+	}
+	return nil
+}
+
+// Row returns a new ValuesTable with the row of values appended to it. The
+// values must be in the same order as the columns.
+func (t ValuesTable) Row(values ...interface{}) ValuesTable {
+	t.RowValues = append(t.RowValues, values)
+	return t
+}
+
+// Cast returns a new ValuesTable whose columns are cast to the types e.g.
+// Cast("INT", "TEXT"). An empty string leaves the type of that column to be
+// inferred from its values.
+func (t ValuesTable) Cast(types ...string) ValuesTable {
+	t.Types = types
+	return t
+}
+
+// As returns a new ValuesTable with the new table Alias.
+func (t ValuesTable) As(alias string) ValuesTable {
+	t.Alias = alias
+	return t
+}
+
+// GetAlias implements the Table interface. It always returns an empty string,
+// because the ValuesTable writes its own alias in AppendSQL.
+func (t ValuesTable) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Table interface. It returns the Alias of the
+// ValuesTable, which is what its columns are qualified with.
+func (t ValuesTable) GetName() string {
+	return t.Alias
+}
+
+// NumberField returns the column of the ValuesTable as a NumberField. An
+// unknown column name writes an error into the SQL query.
+func (t ValuesTable) NumberField(name string) NumberField {
+	return derivedNumberField(t, nil, t.Columns, name)
+}
+
+// StringField returns the column of the ValuesTable as a StringField. An
+// unknown column name writes an error into the SQL query.
+func (t ValuesTable) StringField(name string) StringField {
+	return derivedStringField(t, nil, t.Columns, name)
+}
+
+// TimeField returns the column of the ValuesTable as a TimeField. An unknown
+// column name writes an error into the SQL query.
+func (t ValuesTable) TimeField(name string) TimeField {
+	return derivedTimeField(t, nil, t.Columns, name)
+}
+
+// BooleanField returns the column of the ValuesTable as a BooleanField. An
+// unknown column name writes an error into the SQL query.
+func (t ValuesTable) BooleanField(name string) BooleanField {
+	return derivedBooleanField(t, nil, t.Columns, name)
+}
+
+// JSONField returns the column of the ValuesTable as a JSONField. An unknown
+// column name writes an error into the SQL query.
+func (t ValuesTable) JSONField(name string) JSONField {
+	return derivedJSONField(t, nil, t.Columns, name)
+}
+
+// ArrayField returns the column of the ValuesTable as an ArrayField. An
+// unknown column name writes an error into the SQL query.
+func (t ValuesTable) ArrayField(name string) ArrayField {
+	return derivedArrayField(t, nil, t.Columns, name)
+}
@@ -0,0 +1,110 @@
+package sq
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestValuesTable(t *testing.T) {
+	type TT struct {
+		description string
+		q           Query
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := USERS().As("u")
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	v := NewValuesTable("v", "id", "displayname").
+		Row(1, "alice").
+		Row(2, "bob")
+	tests := []TT{
+		{
+			"SELECT FROM",
+			Select(v.NumberField("id"), v.StringField("displayname")).From(v),
+			"SELECT v.id, v.displayname FROM (VALUES ($1::BIGINT, $2::TEXT), ($3, $4)) AS v (id, displayname)",
+			[]interface{}{1, "alice", 2, "bob"},
+		},
+		{
+			"JOIN",
+			From(u).Join(v, v.NumberField("id").Eq(u.USER_ID)).Select(u.EMAIL),
+			"SELECT u.email FROM public.users AS u JOIN (VALUES ($1::BIGINT, $2::TEXT), ($3, $4)) AS v (id, displayname) ON v.id = u.user_id",
+			[]interface{}{1, "alice", 2, "bob"},
+		},
+		{
+			"UPDATE FROM",
+			Update(u).
+				Set(u.DISPLAYNAME.Set(v.StringField("displayname"))).
+				From(v).
+				Where(u.USER_ID.Eq(v.NumberField("id"))),
+			"UPDATE public.users AS u SET displayname = v.displayname FROM (VALUES ($1::BIGINT, $2::TEXT), ($3, $4)) AS v (id, displayname) WHERE u.user_id = v.id",
+			[]interface{}{1, "alice", 2, "bob"},
+		},
+		{
+			"inferred from later rows",
+			From(NewValuesTable("t", "a", "b", "c").Row(nil, true, []byte("x")).Row(1.5, false, nil)).SelectAll(),
+			"SELECT * FROM (VALUES (NULL::DOUBLE PRECISION, $1::BOOLEAN, $2::BYTEA), ($3, $4, NULL)) AS t (a, b, c)",
+			[]interface{}{true, []byte("x"), 1.5, false},
+		},
+		{
+			"Cast",
+			From(NewValuesTable("t", "id", "created_at", "email").Cast("INT", "", "").Row(1, now, u.EMAIL.Lower())).SelectAll(),
+			"SELECT * FROM (VALUES ($1::INT, $2::TIMESTAMPTZ, LOWER(u.email))) AS t (id, created_at, email)",
+			[]interface{}{1, now},
+		},
+		{
+			"Cast field",
+			From(NewValuesTable("t", "id").Cast("INT").Row(u.USER_ID.AddInt(1))).SelectAll(),
+			"SELECT * FROM (VALUES (CAST(u.user_id + $1 AS INT))) AS t (id)",
+			[]interface{}{1},
+		},
+		{
+			"unknown column",
+			Select(v.NumberField("email")).From(v),
+			"SELECT $1 FROM (VALUES ($2::BIGINT, $3::TEXT), ($4, $5)) AS v (id, displayname)",
+			[]interface{}{&BuildError{Err: errors.New("unknown column email in v")}, 1, "alice", 2, "bob"},
+		},
+		{
+			"no rows",
+			From(NewValuesTable("t", "id")).SelectAll(),
+			"SELECT * FROM $1",
+			[]interface{}{&BuildError{Err: errors.New("ValuesTable t has no rows")}},
+		},
+		{
+			"empty row",
+			From(NewValuesTable("t", "id").Row(1).Row()).SelectAll(),
+			"SELECT * FROM $1",
+			[]interface{}{&BuildError{Err: errors.New("row 2 of ValuesTable t has no values")}},
+		},
+		{
+			"ragged rows",
+			From(NewValuesTable("t").Row(1, "alice").Row(2)).SelectAll(),
+			"SELECT * FROM $1",
+			[]interface{}{&BuildError{Err: errors.New("row 2 of ValuesTable t has 1 values, but row 1 has 2")}},
+		},
+		{
+			"column count mismatch",
+			From(NewValuesTable("t", "id", "displayname").Row(1, "alice", "bob")).SelectAll(),
+			"SELECT * FROM $1",
+			[]interface{}{&BuildError{Err: errors.New("row 1 of ValuesTable t has 3 values, but there are 2 columns")}},
+		},
+		{
+			"slice value",
+			From(NewValuesTable("t", "id", "ids").Row(1, []int{2, 3})).SelectAll(),
+			"SELECT * FROM $1",
+			[]interface{}{&BuildError{Err: errors.New("value 2 of row 1 of ValuesTable t is a slice ([]int), which cannot be a single value")}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package sq
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -132,3 +134,109 @@ func TestVariadicQueries_BasicTesting(t *testing.T) {
 	f.AppendSQLExclude(buf, nil, nil)
 	is.Equal("union_query.some_column", buf.String())
 }
+
+func TestVariadicQueries_ToSQL(t *testing.T) {
+	type TT struct {
+		description string
+		q           VariadicQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u, ur := USERS().As("u"), USER_ROLES().As("ur")
+	q1 := Select(u.USER_ID, u.EMAIL).From(u).Where(u.EMAIL.LikeString("%@a.com"))
+	q2 := Select(ur.USER_ID, ur.ROLE).From(ur).Where(ur.ROLE.EqString("student"))
+	tests := []TT{
+		{
+			"placeholders",
+			Union(q1, q2),
+			"SELECT u.user_id, u.email FROM public.users AS u WHERE u.email LIKE $1" +
+				" UNION SELECT ur.user_id, ur.role FROM public.user_roles AS ur WHERE ur.role = $2",
+			[]interface{}{"%@a.com", "student"},
+		},
+		func() TT {
+			v := UnionAll(q1, q2)
+			return TT{
+				"ORDER BY LIMIT OFFSET",
+				v.OrderBy(v.NumberField("user_id").Desc(), Fieldf("2")).Limit(10).Offset(20),
+				"SELECT u.user_id, u.email FROM public.users AS u WHERE u.email LIKE $1" +
+					" UNION ALL SELECT ur.user_id, ur.role FROM public.user_roles AS ur WHERE ur.role = $2" +
+					" ORDER BY user_id DESC, 2 LIMIT $3 OFFSET $4",
+				[]interface{}{"%@a.com", "student", int64(10), int64(20)},
+			}
+		}(),
+		{
+			"bracketed member with LIMIT",
+			Except(q1.OrderBy(u.USER_ID).Limit(5), q2),
+			"(SELECT u.user_id, u.email FROM public.users AS u WHERE u.email LIKE $1 ORDER BY u.user_id LIMIT $2)" +
+				" EXCEPT SELECT ur.user_id, ur.role FROM public.user_roles AS ur WHERE ur.role = $3",
+			[]interface{}{"%@a.com", int64(5), "student"},
+		},
+		{
+			"bracketed nested query with ORDER BY LIMIT",
+			UnionAll(Union(q1, q2).OrderBy(Fieldf("1")).Limit(5), q1),
+			"(SELECT u.user_id, u.email FROM public.users AS u WHERE u.email LIKE $1" +
+				" UNION SELECT ur.user_id, ur.role FROM public.user_roles AS ur WHERE ur.role = $2" +
+				" ORDER BY 1 LIMIT $3)" +
+				" UNION ALL SELECT u.user_id, u.email FROM public.users AS u WHERE u.email LIKE $4",
+			[]interface{}{"%@a.com", "student", int64(5), "%@a.com"},
+		},
+		{
+			"CTE",
+			With(NewCTE("cte", SelectOne())).Intersect(Select(Fieldf("1")), Select(Fieldf("*")).From(u)),
+			"WITH cte AS (SELECT 1) SELECT 1 INTERSECT SELECT * FROM public.users AS u",
+			nil,
+		},
+		{
+			"column count mismatch",
+			Union(q1, Select(u.USER_ID).From(u)),
+			"$1",
+			[]interface{}{&BuildError{Err: errors.New("UNION query 2 has 1 columns, but query 1 has 2 columns")}},
+		},
+		{
+			"ORDER BY table columns",
+			Union(q1, q2).OrderBy(u.USER_ID, ur.ROLE.Desc()),
+			"SELECT u.user_id, u.email FROM public.users AS u WHERE u.email LIKE $1" +
+				" UNION SELECT ur.user_id, ur.role FROM public.user_roles AS ur WHERE ur.role = $2" +
+				" ORDER BY user_id, role DESC",
+			[]interface{}{"%@a.com", "student"},
+		},
+		func() TT {
+			users := USERS()
+			return TT{
+				"ORDER BY unaliased table columns",
+				Union(Select(users.USER_ID).From(users), Select(ur.USER_ID).From(ur)).OrderBy(users.USER_ID),
+				"SELECT users.user_id FROM public.users" +
+					" UNION SELECT ur.user_id FROM public.user_roles AS ur" +
+					" ORDER BY user_id",
+				nil,
+			}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestVariadicQueries_FetchErrors(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	q := Union(Select(u.USER_ID).From(u), Select(u.USER_ID).From(u))
+	err := q.Fetch(nil)
+	is.True(err != nil) // DB cannot be nil
+	err = q.Fetch(&sql.DB{})
+	is.True(err != nil) // Cannot call Fetch without a mapper
+	err = q.Selectx(func(row *Row) {
+		_ = row.Int(u.USER_ID)
+		_ = row.String(u.EMAIL)
+	}, nil).Fetch(&sql.DB{})
+	is.Equal("the mapper function has 2 fields, but the UNION queries have 1 columns", err.Error())
+	err = Union(Select(u.USER_ID).From(u), Select(u.USER_ID, u.EMAIL).From(u)).SelectRowx(func(row *Row) {}).Fetch(&sql.DB{})
+	is.Equal("UNION query 2 has 2 columns, but query 1 has 1 columns", err.Error())
+}
@@ -12,7 +12,7 @@ type Window struct {
 
 func (w Window) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 	if w.RenderName {
-		buf.WriteString(w.WindowName)
+		appendIdentifier(buf, w.WindowName, false)
 		return
 	}
 	buf.WriteString("(")
@@ -46,12 +46,22 @@ func (w Window) As(name string) Window {
 
 func (w Window) Name() Window {
 	if w.WindowName == "" {
-		w.WindowName = RandomString(8)
+		w.WindowName = w.defaultName()
 	}
 	w.RenderName = true
 	return w
 }
 
+// defaultName returns the name of an unnamed Window. It is derived from the
+// window definition and its values, so identical windows share the same name.
+func (w Window) defaultName() string {
+	w.RenderName = false
+	buf := &strings.Builder{}
+	var args []interface{}
+	w.AppendSQL(buf, &args)
+	return hashName("w_", buf.String(), args)
+}
+
 func PartitionBy(fields ...Field) Window {
 	return Window{
 		PartitionByFields: fields,
@@ -87,9 +97,9 @@ func (ws Windows) AppendSQL(buf *strings.Builder, args *[]interface{}) {
 			buf.WriteString(", ")
 		}
 		if window.WindowName != "" {
-			buf.WriteString(window.WindowName)
+			appendIdentifier(buf, window.WindowName, false)
 		} else {
-			buf.WriteString(RandomString(8))
+			buf.WriteString(window.defaultName())
 		}
 		buf.WriteString(" AS ")
 		window.AppendSQL(buf, args)